@@ -9,6 +9,9 @@ package generator
 import (
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	descriptorpb "google.golang.org/protobuf/types/descriptorpb"
+	durationpb "google.golang.org/protobuf/types/known/durationpb"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
 	pluginpb "google.golang.org/protobuf/types/pluginpb"
 	reflect "reflect"
 	sync "sync"
@@ -26,8 +29,21 @@ type GenerateCodeRequest struct {
 	state                protoimpl.MessageState         `protogen:"open.v1"`
 	CodeGeneratorRequest *pluginpb.CodeGeneratorRequest `protobuf:"bytes,1,opt,name=code_generator_request,json=codeGeneratorRequest,proto3" json:"code_generator_request,omitempty"`
 	PluginName           string                         `protobuf:"bytes,2,opt,name=plugin_name,json=pluginName,proto3" json:"plugin_name,omitempty"`
-	unknownFields        protoimpl.UnknownFields
-	sizeCache            protoimpl.SizeCache
+	// Hashes of previously uploaded descriptors, in dependency order.
+	// They are resolved and placed before the inline proto_file entries of code_generator_request.
+	ProtoFileHashes []string `protobuf:"bytes,3,rep,name=proto_file_hashes,json=protoFileHashes,proto3" json:"proto_file_hashes,omitempty"`
+	// Other plugins generated together with this one, e.g. "protobuf/go:v1.36.9".
+	// They are checked against the compatibility constraints of the plugin.
+	Plugins []string `protobuf:"bytes,4,rep,name=plugins,proto3" json:"plugins,omitempty"`
+	// Overrides the post-processing configured for the plugin.
+	PostProcess *PostProcess `protobuf:"bytes,5,opt,name=post_process,json=postProcess,proto3" json:"post_process,omitempty"`
+	// Dependency modules stored on the server as "<name>@<version>", e.g. "github.com/googleapis/googleapis@v0.0.1".
+	// Files of the modules imported by the request are placed before its files.
+	Modules []string `protobuf:"bytes,6,rep,name=modules,proto3" json:"modules,omitempty"`
+	// Run the plugin twice and compare the outputs byte for byte, the result is stored for the plugin.
+	VerifyDeterminism bool `protobuf:"varint,7,opt,name=verify_determinism,json=verifyDeterminism,proto3" json:"verify_determinism,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
 }
 
 func (x *GenerateCodeRequest) Reset() {
@@ -74,16 +90,131 @@ func (x *GenerateCodeRequest) GetPluginName() string {
 	return ""
 }
 
+func (x *GenerateCodeRequest) GetProtoFileHashes() []string {
+	if x != nil {
+		return x.ProtoFileHashes
+	}
+	return nil
+}
+
+func (x *GenerateCodeRequest) GetPlugins() []string {
+	if x != nil {
+		return x.Plugins
+	}
+	return nil
+}
+
+func (x *GenerateCodeRequest) GetPostProcess() *PostProcess {
+	if x != nil {
+		return x.PostProcess
+	}
+	return nil
+}
+
+func (x *GenerateCodeRequest) GetModules() []string {
+	if x != nil {
+		return x.Modules
+	}
+	return nil
+}
+
+func (x *GenerateCodeRequest) GetVerifyDeterminism() bool {
+	if x != nil {
+		return x.VerifyDeterminism
+	}
+	return false
+}
+
+// PostProcess are steps applied to the generated files, unset fields keep the plugin configuration.
+// Globs match file names: * and ? match within a path segment, ** matches any number of segments.
+type PostProcess struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Header        *string                `protobuf:"bytes,1,opt,name=header,proto3,oneof" json:"header,omitempty"`                           // Prepended to every generated .go file, e.g. a license comment
+	PathPrefix    *string                `protobuf:"bytes,2,opt,name=path_prefix,json=pathPrefix,proto3,oneof" json:"path_prefix,omitempty"` // Prepended to names of the generated files
+	Include       []string               `protobuf:"bytes,3,rep,name=include,proto3" json:"include,omitempty"`                               // Globs of the files to keep, every file is kept when empty
+	Exclude       []string               `protobuf:"bytes,4,rep,name=exclude,proto3" json:"exclude,omitempty"`                               // Globs of the files to drop
+	GoFormat      *bool                  `protobuf:"varint,5,opt,name=go_format,json=goFormat,proto3,oneof" json:"go_format,omitempty"`      // Format .go files with go/format
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PostProcess) Reset() {
+	*x = PostProcess{}
+	mi := &file_api_generator_v1_generator_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PostProcess) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PostProcess) ProtoMessage() {}
+
+func (x *PostProcess) ProtoReflect() protoreflect.Message {
+	mi := &file_api_generator_v1_generator_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PostProcess.ProtoReflect.Descriptor instead.
+func (*PostProcess) Descriptor() ([]byte, []int) {
+	return file_api_generator_v1_generator_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *PostProcess) GetHeader() string {
+	if x != nil && x.Header != nil {
+		return *x.Header
+	}
+	return ""
+}
+
+func (x *PostProcess) GetPathPrefix() string {
+	if x != nil && x.PathPrefix != nil {
+		return *x.PathPrefix
+	}
+	return ""
+}
+
+func (x *PostProcess) GetInclude() []string {
+	if x != nil {
+		return x.Include
+	}
+	return nil
+}
+
+func (x *PostProcess) GetExclude() []string {
+	if x != nil {
+		return x.Exclude
+	}
+	return nil
+}
+
+func (x *PostProcess) GetGoFormat() bool {
+	if x != nil && x.GoFormat != nil {
+		return *x.GoFormat
+	}
+	return false
+}
+
 type GenerateCodeResponse struct {
 	state                 protoimpl.MessageState          `protogen:"open.v1"`
 	CodeGeneratorResponse *pluginpb.CodeGeneratorResponse `protobuf:"bytes,1,opt,name=code_generator_response,json=codeGeneratorResponse,proto3" json:"code_generator_response,omitempty"`
+	Warnings              []string                        `protobuf:"bytes,2,rep,name=warnings,proto3" json:"warnings,omitempty"`       // Violated compatibility constraints of the plugin
+	Determinism           *DeterminismCheck               `protobuf:"bytes,3,opt,name=determinism,proto3" json:"determinism,omitempty"` // Set when the request verifies determinism
 	unknownFields         protoimpl.UnknownFields
 	sizeCache             protoimpl.SizeCache
 }
 
 func (x *GenerateCodeResponse) Reset() {
 	*x = GenerateCodeResponse{}
-	mi := &file_api_generator_v1_generator_proto_msgTypes[1]
+	mi := &file_api_generator_v1_generator_proto_msgTypes[2]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -95,7 +226,7 @@ func (x *GenerateCodeResponse) String() string {
 func (*GenerateCodeResponse) ProtoMessage() {}
 
 func (x *GenerateCodeResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_api_generator_v1_generator_proto_msgTypes[1]
+	mi := &file_api_generator_v1_generator_proto_msgTypes[2]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -108,7 +239,7 @@ func (x *GenerateCodeResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GenerateCodeResponse.ProtoReflect.Descriptor instead.
 func (*GenerateCodeResponse) Descriptor() ([]byte, []int) {
-	return file_api_generator_v1_generator_proto_rawDescGZIP(), []int{1}
+	return file_api_generator_v1_generator_proto_rawDescGZIP(), []int{2}
 }
 
 func (x *GenerateCodeResponse) GetCodeGeneratorResponse() *pluginpb.CodeGeneratorResponse {
@@ -118,20 +249,1153 @@ func (x *GenerateCodeResponse) GetCodeGeneratorResponse() *pluginpb.CodeGenerato
 	return nil
 }
 
-var File_api_generator_v1_generator_proto protoreflect.FileDescriptor
+func (x *GenerateCodeResponse) GetWarnings() []string {
+	if x != nil {
+		return x.Warnings
+	}
+	return nil
+}
 
-const file_api_generator_v1_generator_proto_rawDesc = "" +
-	"\n" +
-	" api/generator/v1/generator.proto\x12\x10api.generator.v1\x1a%google/protobuf/compiler/plugin.proto\"\x9c\x01\n" +
-	"\x13GenerateCodeRequest\x12d\n" +
-	"\x16code_generator_request\x18\x01 \x01(\v2..google.protobuf.compiler.CodeGeneratorRequestR\x14codeGeneratorRequest\x12\x1f\n" +
-	"\vplugin_name\x18\x02 \x01(\tR\n" +
-	"pluginName\"\x7f\n" +
-	"\x14GenerateCodeResponse\x12g\n" +
-	"\x17code_generator_response\x18\x01 \x01(\v2/.google.protobuf.compiler.CodeGeneratorResponseR\x15codeGeneratorResponse2k\n" +
+func (x *GenerateCodeResponse) GetDeterminism() *DeterminismCheck {
+	if x != nil {
+		return x.Determinism
+	}
+	return nil
+}
+
+// DeterminismCheck is the result of running a plugin twice on the same request.
+type DeterminismCheck struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Deterministic bool                   `protobuf:"varint,1,opt,name=deterministic,proto3" json:"deterministic,omitempty"` // The runs succeeded and their outputs are equal
+	// Files whose contents differ, "<name>@<insertion point>" for insertion points and "error" for the plugin error
+	Differences   []string               `protobuf:"bytes,2,rep,name=differences,proto3" json:"differences,omitempty"`
+	Error         string                 `protobuf:"bytes,3,opt,name=error,proto3" json:"error,omitempty"` // Error of the second run, the outputs are not compared then
+	CheckedAt     *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=checked_at,json=checkedAt,proto3" json:"checked_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeterminismCheck) Reset() {
+	*x = DeterminismCheck{}
+	mi := &file_api_generator_v1_generator_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeterminismCheck) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeterminismCheck) ProtoMessage() {}
+
+func (x *DeterminismCheck) ProtoReflect() protoreflect.Message {
+	mi := &file_api_generator_v1_generator_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeterminismCheck.ProtoReflect.Descriptor instead.
+func (*DeterminismCheck) Descriptor() ([]byte, []int) {
+	return file_api_generator_v1_generator_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *DeterminismCheck) GetDeterministic() bool {
+	if x != nil {
+		return x.Deterministic
+	}
+	return false
+}
+
+func (x *DeterminismCheck) GetDifferences() []string {
+	if x != nil {
+		return x.Differences
+	}
+	return nil
+}
+
+func (x *DeterminismCheck) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *DeterminismCheck) GetCheckedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CheckedAt
+	}
+	return nil
+}
+
+type GenerateCodeBatchRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Requests      []*GenerateCodeRequest `protobuf:"bytes,1,rep,name=requests,proto3" json:"requests,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GenerateCodeBatchRequest) Reset() {
+	*x = GenerateCodeBatchRequest{}
+	mi := &file_api_generator_v1_generator_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GenerateCodeBatchRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GenerateCodeBatchRequest) ProtoMessage() {}
+
+func (x *GenerateCodeBatchRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_generator_v1_generator_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GenerateCodeBatchRequest.ProtoReflect.Descriptor instead.
+func (*GenerateCodeBatchRequest) Descriptor() ([]byte, []int) {
+	return file_api_generator_v1_generator_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *GenerateCodeBatchRequest) GetRequests() []*GenerateCodeRequest {
+	if x != nil {
+		return x.Requests
+	}
+	return nil
+}
+
+type GenerateCodeBatchResponse struct {
+	state         protoimpl.MessageState  `protogen:"open.v1"`
+	Responses     []*GenerateCodeResponse `protobuf:"bytes,1,rep,name=responses,proto3" json:"responses,omitempty"` // Responses in request order
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GenerateCodeBatchResponse) Reset() {
+	*x = GenerateCodeBatchResponse{}
+	mi := &file_api_generator_v1_generator_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GenerateCodeBatchResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GenerateCodeBatchResponse) ProtoMessage() {}
+
+func (x *GenerateCodeBatchResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_generator_v1_generator_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GenerateCodeBatchResponse.ProtoReflect.Descriptor instead.
+func (*GenerateCodeBatchResponse) Descriptor() ([]byte, []int) {
+	return file_api_generator_v1_generator_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *GenerateCodeBatchResponse) GetResponses() []*GenerateCodeResponse {
+	if x != nil {
+		return x.Responses
+	}
+	return nil
+}
+
+type DiffGenerationRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Request       *GenerateCodeRequest   `protobuf:"bytes,1,opt,name=request,proto3" json:"request,omitempty"`                               // Generated by both plugins, its plugin_name is ignored
+	BasePlugin    string                 `protobuf:"bytes,2,opt,name=base_plugin,json=basePlugin,proto3" json:"base_plugin,omitempty"`       // e.g. "protobuf/go:v1.36.9"
+	TargetPlugin  string                 `protobuf:"bytes,3,opt,name=target_plugin,json=targetPlugin,proto3" json:"target_plugin,omitempty"` // e.g. "protobuf/go:v1.36.10"
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DiffGenerationRequest) Reset() {
+	*x = DiffGenerationRequest{}
+	mi := &file_api_generator_v1_generator_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DiffGenerationRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DiffGenerationRequest) ProtoMessage() {}
+
+func (x *DiffGenerationRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_generator_v1_generator_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DiffGenerationRequest.ProtoReflect.Descriptor instead.
+func (*DiffGenerationRequest) Descriptor() ([]byte, []int) {
+	return file_api_generator_v1_generator_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *DiffGenerationRequest) GetRequest() *GenerateCodeRequest {
+	if x != nil {
+		return x.Request
+	}
+	return nil
+}
+
+func (x *DiffGenerationRequest) GetBasePlugin() string {
+	if x != nil {
+		return x.BasePlugin
+	}
+	return ""
+}
+
+func (x *DiffGenerationRequest) GetTargetPlugin() string {
+	if x != nil {
+		return x.TargetPlugin
+	}
+	return ""
+}
+
+type DiffGenerationResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Files         []*FileDiff            `protobuf:"bytes,1,rep,name=files,proto3" json:"files,omitempty"` // Files which differ, sorted by name
+	Summary       *DiffSummary           `protobuf:"bytes,2,opt,name=summary,proto3" json:"summary,omitempty"`
+	Base          *GenerateCodeResponse  `protobuf:"bytes,3,opt,name=base,proto3" json:"base,omitempty"`
+	Target        *GenerateCodeResponse  `protobuf:"bytes,4,opt,name=target,proto3" json:"target,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DiffGenerationResponse) Reset() {
+	*x = DiffGenerationResponse{}
+	mi := &file_api_generator_v1_generator_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DiffGenerationResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DiffGenerationResponse) ProtoMessage() {}
+
+func (x *DiffGenerationResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_generator_v1_generator_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DiffGenerationResponse.ProtoReflect.Descriptor instead.
+func (*DiffGenerationResponse) Descriptor() ([]byte, []int) {
+	return file_api_generator_v1_generator_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *DiffGenerationResponse) GetFiles() []*FileDiff {
+	if x != nil {
+		return x.Files
+	}
+	return nil
+}
+
+func (x *DiffGenerationResponse) GetSummary() *DiffSummary {
+	if x != nil {
+		return x.Summary
+	}
+	return nil
+}
+
+func (x *DiffGenerationResponse) GetBase() *GenerateCodeResponse {
+	if x != nil {
+		return x.Base
+	}
+	return nil
+}
+
+func (x *DiffGenerationResponse) GetTarget() *GenerateCodeResponse {
+	if x != nil {
+		return x.Target
+	}
+	return nil
+}
+
+// FileDiff is a file which differs between the outputs, "<name>@<insertion point>" for insertion points.
+type FileDiff struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Status        string                 `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"` // added, removed or modified
+	Diff          string                 `protobuf:"bytes,3,opt,name=diff,proto3" json:"diff,omitempty"`     // Unified diff from the base to the target
+	LinesAdded    int32                  `protobuf:"varint,4,opt,name=lines_added,json=linesAdded,proto3" json:"lines_added,omitempty"`
+	LinesRemoved  int32                  `protobuf:"varint,5,opt,name=lines_removed,json=linesRemoved,proto3" json:"lines_removed,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FileDiff) Reset() {
+	*x = FileDiff{}
+	mi := &file_api_generator_v1_generator_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FileDiff) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FileDiff) ProtoMessage() {}
+
+func (x *FileDiff) ProtoReflect() protoreflect.Message {
+	mi := &file_api_generator_v1_generator_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FileDiff.ProtoReflect.Descriptor instead.
+func (*FileDiff) Descriptor() ([]byte, []int) {
+	return file_api_generator_v1_generator_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *FileDiff) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *FileDiff) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *FileDiff) GetDiff() string {
+	if x != nil {
+		return x.Diff
+	}
+	return ""
+}
+
+func (x *FileDiff) GetLinesAdded() int32 {
+	if x != nil {
+		return x.LinesAdded
+	}
+	return 0
+}
+
+func (x *FileDiff) GetLinesRemoved() int32 {
+	if x != nil {
+		return x.LinesRemoved
+	}
+	return 0
+}
+
+// DiffSummary counts files by status and changed lines.
+type DiffSummary struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Added         int32                  `protobuf:"varint,1,opt,name=added,proto3" json:"added,omitempty"`
+	Removed       int32                  `protobuf:"varint,2,opt,name=removed,proto3" json:"removed,omitempty"`
+	Modified      int32                  `protobuf:"varint,3,opt,name=modified,proto3" json:"modified,omitempty"`
+	Unchanged     int32                  `protobuf:"varint,4,opt,name=unchanged,proto3" json:"unchanged,omitempty"`
+	LinesAdded    int32                  `protobuf:"varint,5,opt,name=lines_added,json=linesAdded,proto3" json:"lines_added,omitempty"`
+	LinesRemoved  int32                  `protobuf:"varint,6,opt,name=lines_removed,json=linesRemoved,proto3" json:"lines_removed,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DiffSummary) Reset() {
+	*x = DiffSummary{}
+	mi := &file_api_generator_v1_generator_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DiffSummary) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DiffSummary) ProtoMessage() {}
+
+func (x *DiffSummary) ProtoReflect() protoreflect.Message {
+	mi := &file_api_generator_v1_generator_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DiffSummary.ProtoReflect.Descriptor instead.
+func (*DiffSummary) Descriptor() ([]byte, []int) {
+	return file_api_generator_v1_generator_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *DiffSummary) GetAdded() int32 {
+	if x != nil {
+		return x.Added
+	}
+	return 0
+}
+
+func (x *DiffSummary) GetRemoved() int32 {
+	if x != nil {
+		return x.Removed
+	}
+	return 0
+}
+
+func (x *DiffSummary) GetModified() int32 {
+	if x != nil {
+		return x.Modified
+	}
+	return 0
+}
+
+func (x *DiffSummary) GetUnchanged() int32 {
+	if x != nil {
+		return x.Unchanged
+	}
+	return 0
+}
+
+func (x *DiffSummary) GetLinesAdded() int32 {
+	if x != nil {
+		return x.LinesAdded
+	}
+	return 0
+}
+
+func (x *DiffSummary) GetLinesRemoved() int32 {
+	if x != nil {
+		return x.LinesRemoved
+	}
+	return 0
+}
+
+type GenerateFromSourcesRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Files []*SourceFile          `protobuf:"bytes,1,rep,name=files,proto3" json:"files,omitempty"`
+	// Dependency modules imported by the files: the linked googleapis (google/api, google/rpc) and
+	// grpc-gateway (protoc-gen-openapiv2/options), or stored ones as "<name>@<version>".
+	// Well-known types are always available.
+	Modules        []string        `protobuf:"bytes,2,rep,name=modules,proto3" json:"modules,omitempty"`
+	FileToGenerate []string        `protobuf:"bytes,3,rep,name=file_to_generate,json=fileToGenerate,proto3" json:"file_to_generate,omitempty"` // Paths of the files to generate, every file when empty
+	Plugins        []*SourcePlugin `protobuf:"bytes,4,rep,name=plugins,proto3" json:"plugins,omitempty"`                                       // Plugins are run together, as by GenerateCodeBatch
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *GenerateFromSourcesRequest) Reset() {
+	*x = GenerateFromSourcesRequest{}
+	mi := &file_api_generator_v1_generator_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GenerateFromSourcesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GenerateFromSourcesRequest) ProtoMessage() {}
+
+func (x *GenerateFromSourcesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_generator_v1_generator_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GenerateFromSourcesRequest.ProtoReflect.Descriptor instead.
+func (*GenerateFromSourcesRequest) Descriptor() ([]byte, []int) {
+	return file_api_generator_v1_generator_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *GenerateFromSourcesRequest) GetFiles() []*SourceFile {
+	if x != nil {
+		return x.Files
+	}
+	return nil
+}
+
+func (x *GenerateFromSourcesRequest) GetModules() []string {
+	if x != nil {
+		return x.Modules
+	}
+	return nil
+}
+
+func (x *GenerateFromSourcesRequest) GetFileToGenerate() []string {
+	if x != nil {
+		return x.FileToGenerate
+	}
+	return nil
+}
+
+func (x *GenerateFromSourcesRequest) GetPlugins() []*SourcePlugin {
+	if x != nil {
+		return x.Plugins
+	}
+	return nil
+}
+
+type SourceFile struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Path          string                 `protobuf:"bytes,1,opt,name=path,proto3" json:"path,omitempty"` // Import path, e.g. "api/v1/api.proto"
+	Content       string                 `protobuf:"bytes,2,opt,name=content,proto3" json:"content,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SourceFile) Reset() {
+	*x = SourceFile{}
+	mi := &file_api_generator_v1_generator_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SourceFile) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SourceFile) ProtoMessage() {}
+
+func (x *SourceFile) ProtoReflect() protoreflect.Message {
+	mi := &file_api_generator_v1_generator_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SourceFile.ProtoReflect.Descriptor instead.
+func (*SourceFile) Descriptor() ([]byte, []int) {
+	return file_api_generator_v1_generator_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *SourceFile) GetPath() string {
+	if x != nil {
+		return x.Path
+	}
+	return ""
+}
+
+func (x *SourceFile) GetContent() string {
+	if x != nil {
+		return x.Content
+	}
+	return ""
+}
+
+type SourcePlugin struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PluginName    string                 `protobuf:"bytes,1,opt,name=plugin_name,json=pluginName,proto3" json:"plugin_name,omitempty"`
+	Parameter     string                 `protobuf:"bytes,2,opt,name=parameter,proto3" json:"parameter,omitempty"`
+	PostProcess   *PostProcess           `protobuf:"bytes,3,opt,name=post_process,json=postProcess,proto3" json:"post_process,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SourcePlugin) Reset() {
+	*x = SourcePlugin{}
+	mi := &file_api_generator_v1_generator_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SourcePlugin) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SourcePlugin) ProtoMessage() {}
+
+func (x *SourcePlugin) ProtoReflect() protoreflect.Message {
+	mi := &file_api_generator_v1_generator_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SourcePlugin.ProtoReflect.Descriptor instead.
+func (*SourcePlugin) Descriptor() ([]byte, []int) {
+	return file_api_generator_v1_generator_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *SourcePlugin) GetPluginName() string {
+	if x != nil {
+		return x.PluginName
+	}
+	return ""
+}
+
+func (x *SourcePlugin) GetParameter() string {
+	if x != nil {
+		return x.Parameter
+	}
+	return ""
+}
+
+func (x *SourcePlugin) GetPostProcess() *PostProcess {
+	if x != nil {
+		return x.PostProcess
+	}
+	return nil
+}
+
+type GenerateFromSourcesResponse struct {
+	state         protoimpl.MessageState  `protogen:"open.v1"`
+	Responses     []*GenerateCodeResponse `protobuf:"bytes,1,rep,name=responses,proto3" json:"responses,omitempty"` // In plugin order, empty when the files don't compile
+	Errors        []*CompileError         `protobuf:"bytes,2,rep,name=errors,proto3" json:"errors,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GenerateFromSourcesResponse) Reset() {
+	*x = GenerateFromSourcesResponse{}
+	mi := &file_api_generator_v1_generator_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GenerateFromSourcesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GenerateFromSourcesResponse) ProtoMessage() {}
+
+func (x *GenerateFromSourcesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_generator_v1_generator_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GenerateFromSourcesResponse.ProtoReflect.Descriptor instead.
+func (*GenerateFromSourcesResponse) Descriptor() ([]byte, []int) {
+	return file_api_generator_v1_generator_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *GenerateFromSourcesResponse) GetResponses() []*GenerateCodeResponse {
+	if x != nil {
+		return x.Responses
+	}
+	return nil
+}
+
+func (x *GenerateFromSourcesResponse) GetErrors() []*CompileError {
+	if x != nil {
+		return x.Errors
+	}
+	return nil
+}
+
+type CompileError struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	File          string                 `protobuf:"bytes,1,opt,name=file,proto3" json:"file,omitempty"`
+	Line          int32                  `protobuf:"varint,2,opt,name=line,proto3" json:"line,omitempty"`     // 1-based, 0 when unknown
+	Column        int32                  `protobuf:"varint,3,opt,name=column,proto3" json:"column,omitempty"` // 1-based, 0 when unknown
+	Message       string                 `protobuf:"bytes,4,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CompileError) Reset() {
+	*x = CompileError{}
+	mi := &file_api_generator_v1_generator_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CompileError) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CompileError) ProtoMessage() {}
+
+func (x *CompileError) ProtoReflect() protoreflect.Message {
+	mi := &file_api_generator_v1_generator_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CompileError.ProtoReflect.Descriptor instead.
+func (*CompileError) Descriptor() ([]byte, []int) {
+	return file_api_generator_v1_generator_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *CompileError) GetFile() string {
+	if x != nil {
+		return x.File
+	}
+	return ""
+}
+
+func (x *CompileError) GetLine() int32 {
+	if x != nil {
+		return x.Line
+	}
+	return 0
+}
+
+func (x *CompileError) GetColumn() int32 {
+	if x != nil {
+		return x.Column
+	}
+	return 0
+}
+
+func (x *CompileError) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+type MissingDescriptorsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Hashes        []string               `protobuf:"bytes,1,rep,name=hashes,proto3" json:"hashes,omitempty"` // Hex-encoded SHA-256 of deterministically serialized FileDescriptorProto
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MissingDescriptorsRequest) Reset() {
+	*x = MissingDescriptorsRequest{}
+	mi := &file_api_generator_v1_generator_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MissingDescriptorsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MissingDescriptorsRequest) ProtoMessage() {}
+
+func (x *MissingDescriptorsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_generator_v1_generator_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MissingDescriptorsRequest.ProtoReflect.Descriptor instead.
+func (*MissingDescriptorsRequest) Descriptor() ([]byte, []int) {
+	return file_api_generator_v1_generator_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *MissingDescriptorsRequest) GetHashes() []string {
+	if x != nil {
+		return x.Hashes
+	}
+	return nil
+}
+
+type MissingDescriptorsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Hashes        []string               `protobuf:"bytes,1,rep,name=hashes,proto3" json:"hashes,omitempty"` // Hashes from the request which the server does not have
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MissingDescriptorsResponse) Reset() {
+	*x = MissingDescriptorsResponse{}
+	mi := &file_api_generator_v1_generator_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MissingDescriptorsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MissingDescriptorsResponse) ProtoMessage() {}
+
+func (x *MissingDescriptorsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_generator_v1_generator_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MissingDescriptorsResponse.ProtoReflect.Descriptor instead.
+func (*MissingDescriptorsResponse) Descriptor() ([]byte, []int) {
+	return file_api_generator_v1_generator_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *MissingDescriptorsResponse) GetHashes() []string {
+	if x != nil {
+		return x.Hashes
+	}
+	return nil
+}
+
+type UploadDescriptorsRequest struct {
+	state         protoimpl.MessageState              `protogen:"open.v1"`
+	ProtoFile     []*descriptorpb.FileDescriptorProto `protobuf:"bytes,1,rep,name=proto_file,json=protoFile,proto3" json:"proto_file,omitempty"` // Descriptors to store
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UploadDescriptorsRequest) Reset() {
+	*x = UploadDescriptorsRequest{}
+	mi := &file_api_generator_v1_generator_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UploadDescriptorsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UploadDescriptorsRequest) ProtoMessage() {}
+
+func (x *UploadDescriptorsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_generator_v1_generator_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UploadDescriptorsRequest.ProtoReflect.Descriptor instead.
+func (*UploadDescriptorsRequest) Descriptor() ([]byte, []int) {
+	return file_api_generator_v1_generator_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *UploadDescriptorsRequest) GetProtoFile() []*descriptorpb.FileDescriptorProto {
+	if x != nil {
+		return x.ProtoFile
+	}
+	return nil
+}
+
+type UploadDescriptorsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Hashes        []string               `protobuf:"bytes,1,rep,name=hashes,proto3" json:"hashes,omitempty"` // Hashes of the stored descriptors, in request order
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UploadDescriptorsResponse) Reset() {
+	*x = UploadDescriptorsResponse{}
+	mi := &file_api_generator_v1_generator_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UploadDescriptorsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UploadDescriptorsResponse) ProtoMessage() {}
+
+func (x *UploadDescriptorsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_generator_v1_generator_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UploadDescriptorsResponse.ProtoReflect.Descriptor instead.
+func (*UploadDescriptorsResponse) Descriptor() ([]byte, []int) {
+	return file_api_generator_v1_generator_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *UploadDescriptorsResponse) GetHashes() []string {
+	if x != nil {
+		return x.Hashes
+	}
+	return nil
+}
+
+// GenerateCodeRecord is a recorded GenerateCode call, the recorder writes one per line in the JSON form.
+type GenerateCodeRecord struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PluginName    string                 `protobuf:"bytes,1,opt,name=plugin_name,json=pluginName,proto3" json:"plugin_name,omitempty"`
+	StartedAt     *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=started_at,json=startedAt,proto3" json:"started_at,omitempty"`
+	Duration      *durationpb.Duration   `protobuf:"bytes,3,opt,name=duration,proto3" json:"duration,omitempty"`
+	Request       []byte                 `protobuf:"bytes,4,opt,name=request,proto3" json:"request,omitempty"`   // Binary GenerateCodeRequest
+	Response      []byte                 `protobuf:"bytes,5,opt,name=response,proto3" json:"response,omitempty"` // Binary GenerateCodeResponse, empty when the call failed
+	Code          uint32                 `protobuf:"varint,6,opt,name=code,proto3" json:"code,omitempty"`        // gRPC status code of the call
+	Error         string                 `protobuf:"bytes,7,opt,name=error,proto3" json:"error,omitempty"`       // Error message of the call
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GenerateCodeRecord) Reset() {
+	*x = GenerateCodeRecord{}
+	mi := &file_api_generator_v1_generator_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GenerateCodeRecord) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GenerateCodeRecord) ProtoMessage() {}
+
+func (x *GenerateCodeRecord) ProtoReflect() protoreflect.Message {
+	mi := &file_api_generator_v1_generator_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GenerateCodeRecord.ProtoReflect.Descriptor instead.
+func (*GenerateCodeRecord) Descriptor() ([]byte, []int) {
+	return file_api_generator_v1_generator_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *GenerateCodeRecord) GetPluginName() string {
+	if x != nil {
+		return x.PluginName
+	}
+	return ""
+}
+
+func (x *GenerateCodeRecord) GetStartedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.StartedAt
+	}
+	return nil
+}
+
+func (x *GenerateCodeRecord) GetDuration() *durationpb.Duration {
+	if x != nil {
+		return x.Duration
+	}
+	return nil
+}
+
+func (x *GenerateCodeRecord) GetRequest() []byte {
+	if x != nil {
+		return x.Request
+	}
+	return nil
+}
+
+func (x *GenerateCodeRecord) GetResponse() []byte {
+	if x != nil {
+		return x.Response
+	}
+	return nil
+}
+
+func (x *GenerateCodeRecord) GetCode() uint32 {
+	if x != nil {
+		return x.Code
+	}
+	return 0
+}
+
+func (x *GenerateCodeRecord) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+var File_api_generator_v1_generator_proto protoreflect.FileDescriptor
+
+const file_api_generator_v1_generator_proto_rawDesc = "" +
+	"\n" +
+	" api/generator/v1/generator.proto\x12\x10api.generator.v1\x1a%google/protobuf/compiler/plugin.proto\x1a google/protobuf/descriptor.proto\x1a\x1egoogle/protobuf/duration.proto\x1a\x1fgoogle/protobuf/timestamp.proto\"\xed\x02\n" +
+	"\x13GenerateCodeRequest\x12d\n" +
+	"\x16code_generator_request\x18\x01 \x01(\v2..google.protobuf.compiler.CodeGeneratorRequestR\x14codeGeneratorRequest\x12\x1f\n" +
+	"\vplugin_name\x18\x02 \x01(\tR\n" +
+	"pluginName\x12*\n" +
+	"\x11proto_file_hashes\x18\x03 \x03(\tR\x0fprotoFileHashes\x12\x18\n" +
+	"\aplugins\x18\x04 \x03(\tR\aplugins\x12@\n" +
+	"\fpost_process\x18\x05 \x01(\v2\x1d.api.generator.v1.PostProcessR\vpostProcess\x12\x18\n" +
+	"\amodules\x18\x06 \x03(\tR\amodules\x12-\n" +
+	"\x12verify_determinism\x18\a \x01(\bR\x11verifyDeterminism\"\xcf\x01\n" +
+	"\vPostProcess\x12\x1b\n" +
+	"\x06header\x18\x01 \x01(\tH\x00R\x06header\x88\x01\x01\x12$\n" +
+	"\vpath_prefix\x18\x02 \x01(\tH\x01R\n" +
+	"pathPrefix\x88\x01\x01\x12\x18\n" +
+	"\ainclude\x18\x03 \x03(\tR\ainclude\x12\x18\n" +
+	"\aexclude\x18\x04 \x03(\tR\aexclude\x12 \n" +
+	"\tgo_format\x18\x05 \x01(\bH\x02R\bgoFormat\x88\x01\x01B\t\n" +
+	"\a_headerB\x0e\n" +
+	"\f_path_prefixB\f\n" +
+	"\n" +
+	"_go_format\"\xe1\x01\n" +
+	"\x14GenerateCodeResponse\x12g\n" +
+	"\x17code_generator_response\x18\x01 \x01(\v2/.google.protobuf.compiler.CodeGeneratorResponseR\x15codeGeneratorResponse\x12\x1a\n" +
+	"\bwarnings\x18\x02 \x03(\tR\bwarnings\x12D\n" +
+	"\vdeterminism\x18\x03 \x01(\v2\".api.generator.v1.DeterminismCheckR\vdeterminism\"\xab\x01\n" +
+	"\x10DeterminismCheck\x12$\n" +
+	"\rdeterministic\x18\x01 \x01(\bR\rdeterministic\x12 \n" +
+	"\vdifferences\x18\x02 \x03(\tR\vdifferences\x12\x14\n" +
+	"\x05error\x18\x03 \x01(\tR\x05error\x129\n" +
+	"\n" +
+	"checked_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\tcheckedAt\"]\n" +
+	"\x18GenerateCodeBatchRequest\x12A\n" +
+	"\brequests\x18\x01 \x03(\v2%.api.generator.v1.GenerateCodeRequestR\brequests\"a\n" +
+	"\x19GenerateCodeBatchResponse\x12D\n" +
+	"\tresponses\x18\x01 \x03(\v2&.api.generator.v1.GenerateCodeResponseR\tresponses\"\x9e\x01\n" +
+	"\x15DiffGenerationRequest\x12?\n" +
+	"\arequest\x18\x01 \x01(\v2%.api.generator.v1.GenerateCodeRequestR\arequest\x12\x1f\n" +
+	"\vbase_plugin\x18\x02 \x01(\tR\n" +
+	"basePlugin\x12#\n" +
+	"\rtarget_plugin\x18\x03 \x01(\tR\ftargetPlugin\"\xff\x01\n" +
+	"\x16DiffGenerationResponse\x120\n" +
+	"\x05files\x18\x01 \x03(\v2\x1a.api.generator.v1.FileDiffR\x05files\x127\n" +
+	"\asummary\x18\x02 \x01(\v2\x1d.api.generator.v1.DiffSummaryR\asummary\x12:\n" +
+	"\x04base\x18\x03 \x01(\v2&.api.generator.v1.GenerateCodeResponseR\x04base\x12>\n" +
+	"\x06target\x18\x04 \x01(\v2&.api.generator.v1.GenerateCodeResponseR\x06target\"\x90\x01\n" +
+	"\bFileDiff\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x16\n" +
+	"\x06status\x18\x02 \x01(\tR\x06status\x12\x12\n" +
+	"\x04diff\x18\x03 \x01(\tR\x04diff\x12\x1f\n" +
+	"\vlines_added\x18\x04 \x01(\x05R\n" +
+	"linesAdded\x12#\n" +
+	"\rlines_removed\x18\x05 \x01(\x05R\flinesRemoved\"\xbd\x01\n" +
+	"\vDiffSummary\x12\x14\n" +
+	"\x05added\x18\x01 \x01(\x05R\x05added\x12\x18\n" +
+	"\aremoved\x18\x02 \x01(\x05R\aremoved\x12\x1a\n" +
+	"\bmodified\x18\x03 \x01(\x05R\bmodified\x12\x1c\n" +
+	"\tunchanged\x18\x04 \x01(\x05R\tunchanged\x12\x1f\n" +
+	"\vlines_added\x18\x05 \x01(\x05R\n" +
+	"linesAdded\x12#\n" +
+	"\rlines_removed\x18\x06 \x01(\x05R\flinesRemoved\"\xce\x01\n" +
+	"\x1aGenerateFromSourcesRequest\x122\n" +
+	"\x05files\x18\x01 \x03(\v2\x1c.api.generator.v1.SourceFileR\x05files\x12\x18\n" +
+	"\amodules\x18\x02 \x03(\tR\amodules\x12(\n" +
+	"\x10file_to_generate\x18\x03 \x03(\tR\x0efileToGenerate\x128\n" +
+	"\aplugins\x18\x04 \x03(\v2\x1e.api.generator.v1.SourcePluginR\aplugins\":\n" +
+	"\n" +
+	"SourceFile\x12\x12\n" +
+	"\x04path\x18\x01 \x01(\tR\x04path\x12\x18\n" +
+	"\acontent\x18\x02 \x01(\tR\acontent\"\x8f\x01\n" +
+	"\fSourcePlugin\x12\x1f\n" +
+	"\vplugin_name\x18\x01 \x01(\tR\n" +
+	"pluginName\x12\x1c\n" +
+	"\tparameter\x18\x02 \x01(\tR\tparameter\x12@\n" +
+	"\fpost_process\x18\x03 \x01(\v2\x1d.api.generator.v1.PostProcessR\vpostProcess\"\x9b\x01\n" +
+	"\x1bGenerateFromSourcesResponse\x12D\n" +
+	"\tresponses\x18\x01 \x03(\v2&.api.generator.v1.GenerateCodeResponseR\tresponses\x126\n" +
+	"\x06errors\x18\x02 \x03(\v2\x1e.api.generator.v1.CompileErrorR\x06errors\"h\n" +
+	"\fCompileError\x12\x12\n" +
+	"\x04file\x18\x01 \x01(\tR\x04file\x12\x12\n" +
+	"\x04line\x18\x02 \x01(\x05R\x04line\x12\x16\n" +
+	"\x06column\x18\x03 \x01(\x05R\x06column\x12\x18\n" +
+	"\amessage\x18\x04 \x01(\tR\amessage\"3\n" +
+	"\x19MissingDescriptorsRequest\x12\x16\n" +
+	"\x06hashes\x18\x01 \x03(\tR\x06hashes\"4\n" +
+	"\x1aMissingDescriptorsResponse\x12\x16\n" +
+	"\x06hashes\x18\x01 \x03(\tR\x06hashes\"_\n" +
+	"\x18UploadDescriptorsRequest\x12C\n" +
+	"\n" +
+	"proto_file\x18\x01 \x03(\v2$.google.protobuf.FileDescriptorProtoR\tprotoFile\"3\n" +
+	"\x19UploadDescriptorsResponse\x12\x16\n" +
+	"\x06hashes\x18\x01 \x03(\tR\x06hashes\"\x87\x02\n" +
+	"\x12GenerateCodeRecord\x12\x1f\n" +
+	"\vplugin_name\x18\x01 \x01(\tR\n" +
+	"pluginName\x129\n" +
+	"\n" +
+	"started_at\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\tstartedAt\x125\n" +
+	"\bduration\x18\x03 \x01(\v2\x19.google.protobuf.DurationR\bduration\x12\x18\n" +
+	"\arequest\x18\x04 \x01(\fR\arequest\x12\x1a\n" +
+	"\bresponse\x18\x05 \x01(\fR\bresponse\x12\x12\n" +
+	"\x04code\x18\x06 \x01(\rR\x04code\x12\x14\n" +
+	"\x05error\x18\a \x01(\tR\x05error2\x91\x05\n" +
 	"\n" +
 	"ServiceAPI\x12]\n" +
-	"\fGenerateCode\x12%.api.generator.v1.GenerateCodeRequest\x1a&.api.generator.v1.GenerateCodeResponseB:Z8github.com/easyp-tech/service/api/generator/v1;generatorb\x06proto3"
+	"\fGenerateCode\x12%.api.generator.v1.GenerateCodeRequest\x1a&.api.generator.v1.GenerateCodeResponse\x12l\n" +
+	"\x11GenerateCodeBatch\x12*.api.generator.v1.GenerateCodeBatchRequest\x1a+.api.generator.v1.GenerateCodeBatchResponse\x12r\n" +
+	"\x13GenerateFromSources\x12,.api.generator.v1.GenerateFromSourcesRequest\x1a-.api.generator.v1.GenerateFromSourcesResponse\x12c\n" +
+	"\x0eDiffGeneration\x12'.api.generator.v1.DiffGenerationRequest\x1a(.api.generator.v1.DiffGenerationResponse\x12o\n" +
+	"\x12MissingDescriptors\x12+.api.generator.v1.MissingDescriptorsRequest\x1a,.api.generator.v1.MissingDescriptorsResponse\x12l\n" +
+	"\x11UploadDescriptors\x12*.api.generator.v1.UploadDescriptorsRequest\x1a+.api.generator.v1.UploadDescriptorsResponseB:Z8github.com/easyp-tech/service/api/generator/v1;generatorb\x06proto3"
 
 var (
 	file_api_generator_v1_generator_proto_rawDescOnce sync.Once
@@ -145,23 +1409,72 @@ func file_api_generator_v1_generator_proto_rawDescGZIP() []byte {
 	return file_api_generator_v1_generator_proto_rawDescData
 }
 
-var file_api_generator_v1_generator_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_api_generator_v1_generator_proto_msgTypes = make([]protoimpl.MessageInfo, 20)
 var file_api_generator_v1_generator_proto_goTypes = []any{
-	(*GenerateCodeRequest)(nil),            // 0: api.generator.v1.GenerateCodeRequest
-	(*GenerateCodeResponse)(nil),           // 1: api.generator.v1.GenerateCodeResponse
-	(*pluginpb.CodeGeneratorRequest)(nil),  // 2: google.protobuf.compiler.CodeGeneratorRequest
-	(*pluginpb.CodeGeneratorResponse)(nil), // 3: google.protobuf.compiler.CodeGeneratorResponse
+	(*GenerateCodeRequest)(nil),              // 0: api.generator.v1.GenerateCodeRequest
+	(*PostProcess)(nil),                      // 1: api.generator.v1.PostProcess
+	(*GenerateCodeResponse)(nil),             // 2: api.generator.v1.GenerateCodeResponse
+	(*DeterminismCheck)(nil),                 // 3: api.generator.v1.DeterminismCheck
+	(*GenerateCodeBatchRequest)(nil),         // 4: api.generator.v1.GenerateCodeBatchRequest
+	(*GenerateCodeBatchResponse)(nil),        // 5: api.generator.v1.GenerateCodeBatchResponse
+	(*DiffGenerationRequest)(nil),            // 6: api.generator.v1.DiffGenerationRequest
+	(*DiffGenerationResponse)(nil),           // 7: api.generator.v1.DiffGenerationResponse
+	(*FileDiff)(nil),                         // 8: api.generator.v1.FileDiff
+	(*DiffSummary)(nil),                      // 9: api.generator.v1.DiffSummary
+	(*GenerateFromSourcesRequest)(nil),       // 10: api.generator.v1.GenerateFromSourcesRequest
+	(*SourceFile)(nil),                       // 11: api.generator.v1.SourceFile
+	(*SourcePlugin)(nil),                     // 12: api.generator.v1.SourcePlugin
+	(*GenerateFromSourcesResponse)(nil),      // 13: api.generator.v1.GenerateFromSourcesResponse
+	(*CompileError)(nil),                     // 14: api.generator.v1.CompileError
+	(*MissingDescriptorsRequest)(nil),        // 15: api.generator.v1.MissingDescriptorsRequest
+	(*MissingDescriptorsResponse)(nil),       // 16: api.generator.v1.MissingDescriptorsResponse
+	(*UploadDescriptorsRequest)(nil),         // 17: api.generator.v1.UploadDescriptorsRequest
+	(*UploadDescriptorsResponse)(nil),        // 18: api.generator.v1.UploadDescriptorsResponse
+	(*GenerateCodeRecord)(nil),               // 19: api.generator.v1.GenerateCodeRecord
+	(*pluginpb.CodeGeneratorRequest)(nil),    // 20: google.protobuf.compiler.CodeGeneratorRequest
+	(*pluginpb.CodeGeneratorResponse)(nil),   // 21: google.protobuf.compiler.CodeGeneratorResponse
+	(*timestamppb.Timestamp)(nil),            // 22: google.protobuf.Timestamp
+	(*descriptorpb.FileDescriptorProto)(nil), // 23: google.protobuf.FileDescriptorProto
+	(*durationpb.Duration)(nil),              // 24: google.protobuf.Duration
 }
 var file_api_generator_v1_generator_proto_depIdxs = []int32{
-	2, // 0: api.generator.v1.GenerateCodeRequest.code_generator_request:type_name -> google.protobuf.compiler.CodeGeneratorRequest
-	3, // 1: api.generator.v1.GenerateCodeResponse.code_generator_response:type_name -> google.protobuf.compiler.CodeGeneratorResponse
-	0, // 2: api.generator.v1.ServiceAPI.GenerateCode:input_type -> api.generator.v1.GenerateCodeRequest
-	1, // 3: api.generator.v1.ServiceAPI.GenerateCode:output_type -> api.generator.v1.GenerateCodeResponse
-	3, // [3:4] is the sub-list for method output_type
-	2, // [2:3] is the sub-list for method input_type
-	2, // [2:2] is the sub-list for extension type_name
-	2, // [2:2] is the sub-list for extension extendee
-	0, // [0:2] is the sub-list for field type_name
+	20, // 0: api.generator.v1.GenerateCodeRequest.code_generator_request:type_name -> google.protobuf.compiler.CodeGeneratorRequest
+	1,  // 1: api.generator.v1.GenerateCodeRequest.post_process:type_name -> api.generator.v1.PostProcess
+	21, // 2: api.generator.v1.GenerateCodeResponse.code_generator_response:type_name -> google.protobuf.compiler.CodeGeneratorResponse
+	3,  // 3: api.generator.v1.GenerateCodeResponse.determinism:type_name -> api.generator.v1.DeterminismCheck
+	22, // 4: api.generator.v1.DeterminismCheck.checked_at:type_name -> google.protobuf.Timestamp
+	0,  // 5: api.generator.v1.GenerateCodeBatchRequest.requests:type_name -> api.generator.v1.GenerateCodeRequest
+	2,  // 6: api.generator.v1.GenerateCodeBatchResponse.responses:type_name -> api.generator.v1.GenerateCodeResponse
+	0,  // 7: api.generator.v1.DiffGenerationRequest.request:type_name -> api.generator.v1.GenerateCodeRequest
+	8,  // 8: api.generator.v1.DiffGenerationResponse.files:type_name -> api.generator.v1.FileDiff
+	9,  // 9: api.generator.v1.DiffGenerationResponse.summary:type_name -> api.generator.v1.DiffSummary
+	2,  // 10: api.generator.v1.DiffGenerationResponse.base:type_name -> api.generator.v1.GenerateCodeResponse
+	2,  // 11: api.generator.v1.DiffGenerationResponse.target:type_name -> api.generator.v1.GenerateCodeResponse
+	11, // 12: api.generator.v1.GenerateFromSourcesRequest.files:type_name -> api.generator.v1.SourceFile
+	12, // 13: api.generator.v1.GenerateFromSourcesRequest.plugins:type_name -> api.generator.v1.SourcePlugin
+	1,  // 14: api.generator.v1.SourcePlugin.post_process:type_name -> api.generator.v1.PostProcess
+	2,  // 15: api.generator.v1.GenerateFromSourcesResponse.responses:type_name -> api.generator.v1.GenerateCodeResponse
+	14, // 16: api.generator.v1.GenerateFromSourcesResponse.errors:type_name -> api.generator.v1.CompileError
+	23, // 17: api.generator.v1.UploadDescriptorsRequest.proto_file:type_name -> google.protobuf.FileDescriptorProto
+	22, // 18: api.generator.v1.GenerateCodeRecord.started_at:type_name -> google.protobuf.Timestamp
+	24, // 19: api.generator.v1.GenerateCodeRecord.duration:type_name -> google.protobuf.Duration
+	0,  // 20: api.generator.v1.ServiceAPI.GenerateCode:input_type -> api.generator.v1.GenerateCodeRequest
+	4,  // 21: api.generator.v1.ServiceAPI.GenerateCodeBatch:input_type -> api.generator.v1.GenerateCodeBatchRequest
+	10, // 22: api.generator.v1.ServiceAPI.GenerateFromSources:input_type -> api.generator.v1.GenerateFromSourcesRequest
+	6,  // 23: api.generator.v1.ServiceAPI.DiffGeneration:input_type -> api.generator.v1.DiffGenerationRequest
+	15, // 24: api.generator.v1.ServiceAPI.MissingDescriptors:input_type -> api.generator.v1.MissingDescriptorsRequest
+	17, // 25: api.generator.v1.ServiceAPI.UploadDescriptors:input_type -> api.generator.v1.UploadDescriptorsRequest
+	2,  // 26: api.generator.v1.ServiceAPI.GenerateCode:output_type -> api.generator.v1.GenerateCodeResponse
+	5,  // 27: api.generator.v1.ServiceAPI.GenerateCodeBatch:output_type -> api.generator.v1.GenerateCodeBatchResponse
+	13, // 28: api.generator.v1.ServiceAPI.GenerateFromSources:output_type -> api.generator.v1.GenerateFromSourcesResponse
+	7,  // 29: api.generator.v1.ServiceAPI.DiffGeneration:output_type -> api.generator.v1.DiffGenerationResponse
+	16, // 30: api.generator.v1.ServiceAPI.MissingDescriptors:output_type -> api.generator.v1.MissingDescriptorsResponse
+	18, // 31: api.generator.v1.ServiceAPI.UploadDescriptors:output_type -> api.generator.v1.UploadDescriptorsResponse
+	26, // [26:32] is the sub-list for method output_type
+	20, // [20:26] is the sub-list for method input_type
+	20, // [20:20] is the sub-list for extension type_name
+	20, // [20:20] is the sub-list for extension extendee
+	0,  // [0:20] is the sub-list for field type_name
 }
 
 func init() { file_api_generator_v1_generator_proto_init() }
@@ -169,13 +1482,14 @@ func file_api_generator_v1_generator_proto_init() {
 	if File_api_generator_v1_generator_proto != nil {
 		return
 	}
+	file_api_generator_v1_generator_proto_msgTypes[1].OneofWrappers = []any{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_api_generator_v1_generator_proto_rawDesc), len(file_api_generator_v1_generator_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   2,
+			NumMessages:   20,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
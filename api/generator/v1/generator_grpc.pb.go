@@ -19,7 +19,12 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	ServiceAPI_GenerateCode_FullMethodName = "/api.generator.v1.ServiceAPI/GenerateCode"
+	ServiceAPI_GenerateCode_FullMethodName        = "/api.generator.v1.ServiceAPI/GenerateCode"
+	ServiceAPI_GenerateCodeBatch_FullMethodName   = "/api.generator.v1.ServiceAPI/GenerateCodeBatch"
+	ServiceAPI_GenerateFromSources_FullMethodName = "/api.generator.v1.ServiceAPI/GenerateFromSources"
+	ServiceAPI_DiffGeneration_FullMethodName      = "/api.generator.v1.ServiceAPI/DiffGeneration"
+	ServiceAPI_MissingDescriptors_FullMethodName  = "/api.generator.v1.ServiceAPI/MissingDescriptors"
+	ServiceAPI_UploadDescriptors_FullMethodName   = "/api.generator.v1.ServiceAPI/UploadDescriptors"
 )
 
 // ServiceAPIClient is the client API for ServiceAPI service.
@@ -27,6 +32,18 @@ const (
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
 type ServiceAPIClient interface {
 	GenerateCode(ctx context.Context, in *GenerateCodeRequest, opts ...grpc.CallOption) (*GenerateCodeResponse, error)
+	// GenerateCodeBatch runs several plugins, each of them is checked against the compatibility
+	// constraints of the others before any of them is run.
+	GenerateCodeBatch(ctx context.Context, in *GenerateCodeBatchRequest, opts ...grpc.CallOption) (*GenerateCodeBatchResponse, error)
+	// GenerateFromSources compiles .proto sources on the server and runs the plugins on them.
+	GenerateFromSources(ctx context.Context, in *GenerateFromSourcesRequest, opts ...grpc.CallOption) (*GenerateFromSourcesResponse, error)
+	// DiffGeneration runs the same request through two plugins, e.g. two versions of one plugin,
+	// and returns the differences of the generated files.
+	DiffGeneration(ctx context.Context, in *DiffGenerationRequest, opts ...grpc.CallOption) (*DiffGenerationResponse, error)
+	// MissingDescriptors reports which of the given descriptor hashes are not stored on the server.
+	MissingDescriptors(ctx context.Context, in *MissingDescriptorsRequest, opts ...grpc.CallOption) (*MissingDescriptorsResponse, error)
+	// UploadDescriptors stores file descriptors so later requests can reference them by hash.
+	UploadDescriptors(ctx context.Context, in *UploadDescriptorsRequest, opts ...grpc.CallOption) (*UploadDescriptorsResponse, error)
 }
 
 type serviceAPIClient struct {
@@ -47,11 +64,73 @@ func (c *serviceAPIClient) GenerateCode(ctx context.Context, in *GenerateCodeReq
 	return out, nil
 }
 
+func (c *serviceAPIClient) GenerateCodeBatch(ctx context.Context, in *GenerateCodeBatchRequest, opts ...grpc.CallOption) (*GenerateCodeBatchResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GenerateCodeBatchResponse)
+	err := c.cc.Invoke(ctx, ServiceAPI_GenerateCodeBatch_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *serviceAPIClient) GenerateFromSources(ctx context.Context, in *GenerateFromSourcesRequest, opts ...grpc.CallOption) (*GenerateFromSourcesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GenerateFromSourcesResponse)
+	err := c.cc.Invoke(ctx, ServiceAPI_GenerateFromSources_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *serviceAPIClient) DiffGeneration(ctx context.Context, in *DiffGenerationRequest, opts ...grpc.CallOption) (*DiffGenerationResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DiffGenerationResponse)
+	err := c.cc.Invoke(ctx, ServiceAPI_DiffGeneration_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *serviceAPIClient) MissingDescriptors(ctx context.Context, in *MissingDescriptorsRequest, opts ...grpc.CallOption) (*MissingDescriptorsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(MissingDescriptorsResponse)
+	err := c.cc.Invoke(ctx, ServiceAPI_MissingDescriptors_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *serviceAPIClient) UploadDescriptors(ctx context.Context, in *UploadDescriptorsRequest, opts ...grpc.CallOption) (*UploadDescriptorsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UploadDescriptorsResponse)
+	err := c.cc.Invoke(ctx, ServiceAPI_UploadDescriptors_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // ServiceAPIServer is the server API for ServiceAPI service.
 // All implementations should embed UnimplementedServiceAPIServer
 // for forward compatibility.
 type ServiceAPIServer interface {
 	GenerateCode(context.Context, *GenerateCodeRequest) (*GenerateCodeResponse, error)
+	// GenerateCodeBatch runs several plugins, each of them is checked against the compatibility
+	// constraints of the others before any of them is run.
+	GenerateCodeBatch(context.Context, *GenerateCodeBatchRequest) (*GenerateCodeBatchResponse, error)
+	// GenerateFromSources compiles .proto sources on the server and runs the plugins on them.
+	GenerateFromSources(context.Context, *GenerateFromSourcesRequest) (*GenerateFromSourcesResponse, error)
+	// DiffGeneration runs the same request through two plugins, e.g. two versions of one plugin,
+	// and returns the differences of the generated files.
+	DiffGeneration(context.Context, *DiffGenerationRequest) (*DiffGenerationResponse, error)
+	// MissingDescriptors reports which of the given descriptor hashes are not stored on the server.
+	MissingDescriptors(context.Context, *MissingDescriptorsRequest) (*MissingDescriptorsResponse, error)
+	// UploadDescriptors stores file descriptors so later requests can reference them by hash.
+	UploadDescriptors(context.Context, *UploadDescriptorsRequest) (*UploadDescriptorsResponse, error)
 }
 
 // UnimplementedServiceAPIServer should be embedded to have
@@ -64,6 +143,21 @@ type UnimplementedServiceAPIServer struct{}
 func (UnimplementedServiceAPIServer) GenerateCode(context.Context, *GenerateCodeRequest) (*GenerateCodeResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GenerateCode not implemented")
 }
+func (UnimplementedServiceAPIServer) GenerateCodeBatch(context.Context, *GenerateCodeBatchRequest) (*GenerateCodeBatchResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GenerateCodeBatch not implemented")
+}
+func (UnimplementedServiceAPIServer) GenerateFromSources(context.Context, *GenerateFromSourcesRequest) (*GenerateFromSourcesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GenerateFromSources not implemented")
+}
+func (UnimplementedServiceAPIServer) DiffGeneration(context.Context, *DiffGenerationRequest) (*DiffGenerationResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DiffGeneration not implemented")
+}
+func (UnimplementedServiceAPIServer) MissingDescriptors(context.Context, *MissingDescriptorsRequest) (*MissingDescriptorsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method MissingDescriptors not implemented")
+}
+func (UnimplementedServiceAPIServer) UploadDescriptors(context.Context, *UploadDescriptorsRequest) (*UploadDescriptorsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UploadDescriptors not implemented")
+}
 func (UnimplementedServiceAPIServer) testEmbeddedByValue() {}
 
 // UnsafeServiceAPIServer may be embedded to opt out of forward compatibility for this service.
@@ -102,6 +196,96 @@ func _ServiceAPI_GenerateCode_Handler(srv interface{}, ctx context.Context, dec
 	return interceptor(ctx, in, info, handler)
 }
 
+func _ServiceAPI_GenerateCodeBatch_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GenerateCodeBatchRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ServiceAPIServer).GenerateCodeBatch(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ServiceAPI_GenerateCodeBatch_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ServiceAPIServer).GenerateCodeBatch(ctx, req.(*GenerateCodeBatchRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ServiceAPI_GenerateFromSources_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GenerateFromSourcesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ServiceAPIServer).GenerateFromSources(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ServiceAPI_GenerateFromSources_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ServiceAPIServer).GenerateFromSources(ctx, req.(*GenerateFromSourcesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ServiceAPI_DiffGeneration_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DiffGenerationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ServiceAPIServer).DiffGeneration(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ServiceAPI_DiffGeneration_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ServiceAPIServer).DiffGeneration(ctx, req.(*DiffGenerationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ServiceAPI_MissingDescriptors_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MissingDescriptorsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ServiceAPIServer).MissingDescriptors(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ServiceAPI_MissingDescriptors_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ServiceAPIServer).MissingDescriptors(ctx, req.(*MissingDescriptorsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ServiceAPI_UploadDescriptors_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UploadDescriptorsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ServiceAPIServer).UploadDescriptors(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ServiceAPI_UploadDescriptors_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ServiceAPIServer).UploadDescriptors(ctx, req.(*UploadDescriptorsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // ServiceAPI_ServiceDesc is the grpc.ServiceDesc for ServiceAPI service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -113,6 +297,26 @@ var ServiceAPI_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GenerateCode",
 			Handler:    _ServiceAPI_GenerateCode_Handler,
 		},
+		{
+			MethodName: "GenerateCodeBatch",
+			Handler:    _ServiceAPI_GenerateCodeBatch_Handler,
+		},
+		{
+			MethodName: "GenerateFromSources",
+			Handler:    _ServiceAPI_GenerateFromSources_Handler,
+		},
+		{
+			MethodName: "DiffGeneration",
+			Handler:    _ServiceAPI_DiffGeneration_Handler,
+		},
+		{
+			MethodName: "MissingDescriptors",
+			Handler:    _ServiceAPI_MissingDescriptors_Handler,
+		},
+		{
+			MethodName: "UploadDescriptors",
+			Handler:    _ServiceAPI_UploadDescriptors_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "api/generator/v1/generator.proto",
@@ -10,6 +10,8 @@ import (
 	_ "google.golang.org/genproto/googleapis/api/annotations"
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	descriptorpb "google.golang.org/protobuf/types/descriptorpb"
+	structpb "google.golang.org/protobuf/types/known/structpb"
 	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
 	reflect "reflect"
 	sync "sync"
@@ -103,21 +105,1295 @@ func (x *PluginsResponse) GetPlugins() []*PluginInfo {
 	return nil
 }
 
+type RegisterPluginRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Group          string                 `protobuf:"bytes,1,opt,name=group,proto3" json:"group,omitempty"`                                          // Group of the plugin, e.g. "protobuf"
+	Name           string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`                                            // Name of the plugin, e.g. "go"
+	Version        string                 `protobuf:"bytes,3,opt,name=version,proto3" json:"version,omitempty"`                                      // Version of the plugin, e.g. "v1.36.10"
+	Config         *structpb.Struct       `protobuf:"bytes,4,opt,name=config,proto3" json:"config,omitempty"`                                        // Execution config of the plugin, see PluginConfig
+	Image          string                 `protobuf:"bytes,5,opt,name=image,proto3" json:"image,omitempty"`                                          // Explicit image reference, e.g. "ghcr.io/acme/protoc-gen-foo:v1.2.0"
+	Registry       string                 `protobuf:"bytes,6,opt,name=registry,proto3" json:"registry,omitempty"`                                    // Name of a configured upstream registry, the default one when empty
+	RunConformance bool                   `protobuf:"varint,7,opt,name=run_conformance,json=runConformance,proto3" json:"run_conformance,omitempty"` // Run the conformance suite of the plugin after the registration
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *RegisterPluginRequest) Reset() {
+	*x = RegisterPluginRequest{}
+	mi := &file_api_web_v1_web_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RegisterPluginRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RegisterPluginRequest) ProtoMessage() {}
+
+func (x *RegisterPluginRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_web_v1_web_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RegisterPluginRequest.ProtoReflect.Descriptor instead.
+func (*RegisterPluginRequest) Descriptor() ([]byte, []int) {
+	return file_api_web_v1_web_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *RegisterPluginRequest) GetGroup() string {
+	if x != nil {
+		return x.Group
+	}
+	return ""
+}
+
+func (x *RegisterPluginRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *RegisterPluginRequest) GetVersion() string {
+	if x != nil {
+		return x.Version
+	}
+	return ""
+}
+
+func (x *RegisterPluginRequest) GetConfig() *structpb.Struct {
+	if x != nil {
+		return x.Config
+	}
+	return nil
+}
+
+func (x *RegisterPluginRequest) GetImage() string {
+	if x != nil {
+		return x.Image
+	}
+	return ""
+}
+
+func (x *RegisterPluginRequest) GetRegistry() string {
+	if x != nil {
+		return x.Registry
+	}
+	return ""
+}
+
+func (x *RegisterPluginRequest) GetRunConformance() bool {
+	if x != nil {
+		return x.RunConformance
+	}
+	return false
+}
+
+type RegisterPluginResponse struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	Plugin           *PluginInfo            `protobuf:"bytes,1,opt,name=plugin,proto3" json:"plugin,omitempty"`
+	Conformance      *ConformanceReport     `protobuf:"bytes,2,opt,name=conformance,proto3" json:"conformance,omitempty"`                                   // Set when run_conformance is set and the suite ran
+	ConformanceError string                 `protobuf:"bytes,3,opt,name=conformance_error,json=conformanceError,proto3" json:"conformance_error,omitempty"` // Error of the conformance suite, the plugin is registered anyway
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *RegisterPluginResponse) Reset() {
+	*x = RegisterPluginResponse{}
+	mi := &file_api_web_v1_web_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RegisterPluginResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RegisterPluginResponse) ProtoMessage() {}
+
+func (x *RegisterPluginResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_web_v1_web_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RegisterPluginResponse.ProtoReflect.Descriptor instead.
+func (*RegisterPluginResponse) Descriptor() ([]byte, []int) {
+	return file_api_web_v1_web_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *RegisterPluginResponse) GetPlugin() *PluginInfo {
+	if x != nil {
+		return x.Plugin
+	}
+	return nil
+}
+
+func (x *RegisterPluginResponse) GetConformance() *ConformanceReport {
+	if x != nil {
+		return x.Conformance
+	}
+	return nil
+}
+
+func (x *RegisterPluginResponse) GetConformanceError() string {
+	if x != nil {
+		return x.ConformanceError
+	}
+	return ""
+}
+
+type RunConformanceRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Plugin        string                 `protobuf:"bytes,1,opt,name=plugin,proto3" json:"plugin,omitempty"` // Plugin name, e.g. "protobuf/go:v1.36.10"
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RunConformanceRequest) Reset() {
+	*x = RunConformanceRequest{}
+	mi := &file_api_web_v1_web_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RunConformanceRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RunConformanceRequest) ProtoMessage() {}
+
+func (x *RunConformanceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_web_v1_web_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RunConformanceRequest.ProtoReflect.Descriptor instead.
+func (*RunConformanceRequest) Descriptor() ([]byte, []int) {
+	return file_api_web_v1_web_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *RunConformanceRequest) GetPlugin() string {
+	if x != nil {
+		return x.Plugin
+	}
+	return ""
+}
+
+type RunConformanceResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Report        *ConformanceReport     `protobuf:"bytes,1,opt,name=report,proto3" json:"report,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RunConformanceResponse) Reset() {
+	*x = RunConformanceResponse{}
+	mi := &file_api_web_v1_web_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RunConformanceResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RunConformanceResponse) ProtoMessage() {}
+
+func (x *RunConformanceResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_web_v1_web_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RunConformanceResponse.ProtoReflect.Descriptor instead.
+func (*RunConformanceResponse) Descriptor() ([]byte, []int) {
+	return file_api_web_v1_web_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *RunConformanceResponse) GetReport() *ConformanceReport {
+	if x != nil {
+		return x.Report
+	}
+	return nil
+}
+
+// ConformanceReport message represents the results of the fixtures of a plugin.
+type ConformanceReport struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Plugin        string                 `protobuf:"bytes,1,opt,name=plugin,proto3" json:"plugin,omitempty"`
+	Cases         []*ConformanceCase     `protobuf:"bytes,2,rep,name=cases,proto3" json:"cases,omitempty"`
+	Passed        int32                  `protobuf:"varint,3,opt,name=passed,proto3" json:"passed,omitempty"`
+	Failed        int32                  `protobuf:"varint,4,opt,name=failed,proto3" json:"failed,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ConformanceReport) Reset() {
+	*x = ConformanceReport{}
+	mi := &file_api_web_v1_web_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ConformanceReport) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConformanceReport) ProtoMessage() {}
+
+func (x *ConformanceReport) ProtoReflect() protoreflect.Message {
+	mi := &file_api_web_v1_web_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ConformanceReport.ProtoReflect.Descriptor instead.
+func (*ConformanceReport) Descriptor() ([]byte, []int) {
+	return file_api_web_v1_web_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *ConformanceReport) GetPlugin() string {
+	if x != nil {
+		return x.Plugin
+	}
+	return ""
+}
+
+func (x *ConformanceReport) GetCases() []*ConformanceCase {
+	if x != nil {
+		return x.Cases
+	}
+	return nil
+}
+
+func (x *ConformanceReport) GetPassed() int32 {
+	if x != nil {
+		return x.Passed
+	}
+	return 0
+}
+
+func (x *ConformanceReport) GetFailed() int32 {
+	if x != nil {
+		return x.Failed
+	}
+	return 0
+}
+
+// ConformanceCase message represents the result of a fixture.
+type ConformanceCase struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Passed        bool                   `protobuf:"varint,2,opt,name=passed,proto3" json:"passed,omitempty"`
+	Error         string                 `protobuf:"bytes,3,opt,name=error,proto3" json:"error,omitempty"` // Error of the run or mismatch of the plugin error and the supported features
+	Files         []*ConformanceFileDiff `protobuf:"bytes,4,rep,name=files,proto3" json:"files,omitempty"` // Differences from the expected files to the generated ones
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ConformanceCase) Reset() {
+	*x = ConformanceCase{}
+	mi := &file_api_web_v1_web_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ConformanceCase) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConformanceCase) ProtoMessage() {}
+
+func (x *ConformanceCase) ProtoReflect() protoreflect.Message {
+	mi := &file_api_web_v1_web_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ConformanceCase.ProtoReflect.Descriptor instead.
+func (*ConformanceCase) Descriptor() ([]byte, []int) {
+	return file_api_web_v1_web_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *ConformanceCase) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *ConformanceCase) GetPassed() bool {
+	if x != nil {
+		return x.Passed
+	}
+	return false
+}
+
+func (x *ConformanceCase) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *ConformanceCase) GetFiles() []*ConformanceFileDiff {
+	if x != nil {
+		return x.Files
+	}
+	return nil
+}
+
+// ConformanceFileDiff message represents a file which differs from the expected one.
+type ConformanceFileDiff struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Status        string                 `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"` // added, removed or modified
+	Diff          string                 `protobuf:"bytes,3,opt,name=diff,proto3" json:"diff,omitempty"`     // Unified diff from the expected file
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ConformanceFileDiff) Reset() {
+	*x = ConformanceFileDiff{}
+	mi := &file_api_web_v1_web_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ConformanceFileDiff) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConformanceFileDiff) ProtoMessage() {}
+
+func (x *ConformanceFileDiff) ProtoReflect() protoreflect.Message {
+	mi := &file_api_web_v1_web_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ConformanceFileDiff.ProtoReflect.Descriptor instead.
+func (*ConformanceFileDiff) Descriptor() ([]byte, []int) {
+	return file_api_web_v1_web_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *ConformanceFileDiff) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *ConformanceFileDiff) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *ConformanceFileDiff) GetDiff() string {
+	if x != nil {
+		return x.Diff
+	}
+	return ""
+}
+
+type SecretsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SecretsRequest) Reset() {
+	*x = SecretsRequest{}
+	mi := &file_api_web_v1_web_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SecretsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SecretsRequest) ProtoMessage() {}
+
+func (x *SecretsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_web_v1_web_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SecretsRequest.ProtoReflect.Descriptor instead.
+func (*SecretsRequest) Descriptor() ([]byte, []int) {
+	return file_api_web_v1_web_proto_rawDescGZIP(), []int{9}
+}
+
+type SecretsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Secrets       []*SecretInfo          `protobuf:"bytes,1,rep,name=secrets,proto3" json:"secrets,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SecretsResponse) Reset() {
+	*x = SecretsResponse{}
+	mi := &file_api_web_v1_web_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SecretsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SecretsResponse) ProtoMessage() {}
+
+func (x *SecretsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_web_v1_web_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SecretsResponse.ProtoReflect.Descriptor instead.
+func (*SecretsResponse) Descriptor() ([]byte, []int) {
+	return file_api_web_v1_web_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *SecretsResponse) GetSecrets() []*SecretInfo {
+	if x != nil {
+		return x.Secrets
+	}
+	return nil
+}
+
+type PutSecretRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`   // Name of the secret, e.g. "buf-license"
+	Value         string                 `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"` // Plain text value, encrypted at rest
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PutSecretRequest) Reset() {
+	*x = PutSecretRequest{}
+	mi := &file_api_web_v1_web_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PutSecretRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PutSecretRequest) ProtoMessage() {}
+
+func (x *PutSecretRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_web_v1_web_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PutSecretRequest.ProtoReflect.Descriptor instead.
+func (*PutSecretRequest) Descriptor() ([]byte, []int) {
+	return file_api_web_v1_web_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *PutSecretRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *PutSecretRequest) GetValue() string {
+	if x != nil {
+		return x.Value
+	}
+	return ""
+}
+
+type PutSecretResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Secret        *SecretInfo            `protobuf:"bytes,1,opt,name=secret,proto3" json:"secret,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PutSecretResponse) Reset() {
+	*x = PutSecretResponse{}
+	mi := &file_api_web_v1_web_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PutSecretResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PutSecretResponse) ProtoMessage() {}
+
+func (x *PutSecretResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_web_v1_web_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PutSecretResponse.ProtoReflect.Descriptor instead.
+func (*PutSecretResponse) Descriptor() ([]byte, []int) {
+	return file_api_web_v1_web_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *PutSecretResponse) GetSecret() *SecretInfo {
+	if x != nil {
+		return x.Secret
+	}
+	return nil
+}
+
+type DeleteSecretRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteSecretRequest) Reset() {
+	*x = DeleteSecretRequest{}
+	mi := &file_api_web_v1_web_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteSecretRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteSecretRequest) ProtoMessage() {}
+
+func (x *DeleteSecretRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_web_v1_web_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteSecretRequest.ProtoReflect.Descriptor instead.
+func (*DeleteSecretRequest) Descriptor() ([]byte, []int) {
+	return file_api_web_v1_web_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *DeleteSecretRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+type DeleteSecretResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteSecretResponse) Reset() {
+	*x = DeleteSecretResponse{}
+	mi := &file_api_web_v1_web_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteSecretResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteSecretResponse) ProtoMessage() {}
+
+func (x *DeleteSecretResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_web_v1_web_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteSecretResponse.ProtoReflect.Descriptor instead.
+func (*DeleteSecretResponse) Descriptor() ([]byte, []int) {
+	return file_api_web_v1_web_proto_rawDescGZIP(), []int{14}
+}
+
+type AddSignatureRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Digest        string                 `protobuf:"bytes,1,opt,name=digest,proto3" json:"digest,omitempty"`       // Image digest, e.g. "sha256:..."
+	Payload       []byte                 `protobuf:"bytes,2,opt,name=payload,proto3" json:"payload,omitempty"`     // Cosign simple signing payload of the digest
+	Signature     string                 `protobuf:"bytes,3,opt,name=signature,proto3" json:"signature,omitempty"` // Base64 encoded signature of the payload
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AddSignatureRequest) Reset() {
+	*x = AddSignatureRequest{}
+	mi := &file_api_web_v1_web_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AddSignatureRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddSignatureRequest) ProtoMessage() {}
+
+func (x *AddSignatureRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_web_v1_web_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddSignatureRequest.ProtoReflect.Descriptor instead.
+func (*AddSignatureRequest) Descriptor() ([]byte, []int) {
+	return file_api_web_v1_web_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *AddSignatureRequest) GetDigest() string {
+	if x != nil {
+		return x.Digest
+	}
+	return ""
+}
+
+func (x *AddSignatureRequest) GetPayload() []byte {
+	if x != nil {
+		return x.Payload
+	}
+	return nil
+}
+
+func (x *AddSignatureRequest) GetSignature() string {
+	if x != nil {
+		return x.Signature
+	}
+	return ""
+}
+
+type AddSignatureResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AddSignatureResponse) Reset() {
+	*x = AddSignatureResponse{}
+	mi := &file_api_web_v1_web_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AddSignatureResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddSignatureResponse) ProtoMessage() {}
+
+func (x *AddSignatureResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_web_v1_web_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddSignatureResponse.ProtoReflect.Descriptor instead.
+func (*AddSignatureResponse) Descriptor() ([]byte, []int) {
+	return file_api_web_v1_web_proto_rawDescGZIP(), []int{16}
+}
+
+type SyncCatalogRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SyncCatalogRequest) Reset() {
+	*x = SyncCatalogRequest{}
+	mi := &file_api_web_v1_web_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SyncCatalogRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SyncCatalogRequest) ProtoMessage() {}
+
+func (x *SyncCatalogRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_web_v1_web_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SyncCatalogRequest.ProtoReflect.Descriptor instead.
+func (*SyncCatalogRequest) Descriptor() ([]byte, []int) {
+	return file_api_web_v1_web_proto_rawDescGZIP(), []int{17}
+}
+
+type SyncCatalogResponse struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Created         []*PluginInfo          `protobuf:"bytes,1,rep,name=created,proto3" json:"created,omitempty"`                                        // Plugins registered from new images
+	Updated         []*PluginInfo          `protobuf:"bytes,2,rep,name=updated,proto3" json:"updated,omitempty"`                                        // Plugins whose config was replaced by the image label
+	OrphanedPlugins []*PluginInfo          `protobuf:"bytes,3,rep,name=orphaned_plugins,json=orphanedPlugins,proto3" json:"orphaned_plugins,omitempty"` // Plugins of the default registry without an image
+	OrphanedImages  []*OrphanedImage       `protobuf:"bytes,4,rep,name=orphaned_images,json=orphanedImages,proto3" json:"orphaned_images,omitempty"`    // Images which can't be registered
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *SyncCatalogResponse) Reset() {
+	*x = SyncCatalogResponse{}
+	mi := &file_api_web_v1_web_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SyncCatalogResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SyncCatalogResponse) ProtoMessage() {}
+
+func (x *SyncCatalogResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_web_v1_web_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SyncCatalogResponse.ProtoReflect.Descriptor instead.
+func (*SyncCatalogResponse) Descriptor() ([]byte, []int) {
+	return file_api_web_v1_web_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *SyncCatalogResponse) GetCreated() []*PluginInfo {
+	if x != nil {
+		return x.Created
+	}
+	return nil
+}
+
+func (x *SyncCatalogResponse) GetUpdated() []*PluginInfo {
+	if x != nil {
+		return x.Updated
+	}
+	return nil
+}
+
+func (x *SyncCatalogResponse) GetOrphanedPlugins() []*PluginInfo {
+	if x != nil {
+		return x.OrphanedPlugins
+	}
+	return nil
+}
+
+func (x *SyncCatalogResponse) GetOrphanedImages() []*OrphanedImage {
+	if x != nil {
+		return x.OrphanedImages
+	}
+	return nil
+}
+
+// OrphanedImage message represents an image which can't be registered as a plugin.
+type OrphanedImage struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Image         string                 `protobuf:"bytes,1,opt,name=image,proto3" json:"image,omitempty"`
+	Reason        string                 `protobuf:"bytes,2,opt,name=reason,proto3" json:"reason,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *OrphanedImage) Reset() {
+	*x = OrphanedImage{}
+	mi := &file_api_web_v1_web_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OrphanedImage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OrphanedImage) ProtoMessage() {}
+
+func (x *OrphanedImage) ProtoReflect() protoreflect.Message {
+	mi := &file_api_web_v1_web_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OrphanedImage.ProtoReflect.Descriptor instead.
+func (*OrphanedImage) Descriptor() ([]byte, []int) {
+	return file_api_web_v1_web_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *OrphanedImage) GetImage() string {
+	if x != nil {
+		return x.Image
+	}
+	return ""
+}
+
+func (x *OrphanedImage) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+// SecretInfo message represents a stored secret without its value.
+type SecretInfo struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SecretInfo) Reset() {
+	*x = SecretInfo{}
+	mi := &file_api_web_v1_web_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SecretInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SecretInfo) ProtoMessage() {}
+
+func (x *SecretInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_api_web_v1_web_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SecretInfo.ProtoReflect.Descriptor instead.
+func (*SecretInfo) Descriptor() ([]byte, []int) {
+	return file_api_web_v1_web_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *SecretInfo) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *SecretInfo) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *SecretInfo) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+type ModulesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ModulesRequest) Reset() {
+	*x = ModulesRequest{}
+	mi := &file_api_web_v1_web_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ModulesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ModulesRequest) ProtoMessage() {}
+
+func (x *ModulesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_web_v1_web_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ModulesRequest.ProtoReflect.Descriptor instead.
+func (*ModulesRequest) Descriptor() ([]byte, []int) {
+	return file_api_web_v1_web_proto_rawDescGZIP(), []int{21}
+}
+
+type ModulesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Modules       []*ModuleInfo          `protobuf:"bytes,1,rep,name=modules,proto3" json:"modules,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ModulesResponse) Reset() {
+	*x = ModulesResponse{}
+	mi := &file_api_web_v1_web_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ModulesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ModulesResponse) ProtoMessage() {}
+
+func (x *ModulesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_web_v1_web_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ModulesResponse.ProtoReflect.Descriptor instead.
+func (*ModulesResponse) Descriptor() ([]byte, []int) {
+	return file_api_web_v1_web_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *ModulesResponse) GetModules() []*ModuleInfo {
+	if x != nil {
+		return x.Modules
+	}
+	return nil
+}
+
+type PutModuleRequest struct {
+	state         protoimpl.MessageState          `protogen:"open.v1"`
+	Name          string                          `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`                                        // Name of the module, e.g. "github.com/googleapis/googleapis"
+	Version       string                          `protobuf:"bytes,2,opt,name=version,proto3" json:"version,omitempty"`                                  // Version of the module, e.g. "v0.0.1"
+	DescriptorSet *descriptorpb.FileDescriptorSet `protobuf:"bytes,3,opt,name=descriptor_set,json=descriptorSet,proto3" json:"descriptor_set,omitempty"` // Files of the module
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PutModuleRequest) Reset() {
+	*x = PutModuleRequest{}
+	mi := &file_api_web_v1_web_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PutModuleRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PutModuleRequest) ProtoMessage() {}
+
+func (x *PutModuleRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_web_v1_web_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PutModuleRequest.ProtoReflect.Descriptor instead.
+func (*PutModuleRequest) Descriptor() ([]byte, []int) {
+	return file_api_web_v1_web_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *PutModuleRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *PutModuleRequest) GetVersion() string {
+	if x != nil {
+		return x.Version
+	}
+	return ""
+}
+
+func (x *PutModuleRequest) GetDescriptorSet() *descriptorpb.FileDescriptorSet {
+	if x != nil {
+		return x.DescriptorSet
+	}
+	return nil
+}
+
+type PutModuleResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Module        *ModuleInfo            `protobuf:"bytes,1,opt,name=module,proto3" json:"module,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PutModuleResponse) Reset() {
+	*x = PutModuleResponse{}
+	mi := &file_api_web_v1_web_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PutModuleResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PutModuleResponse) ProtoMessage() {}
+
+func (x *PutModuleResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_web_v1_web_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PutModuleResponse.ProtoReflect.Descriptor instead.
+func (*PutModuleResponse) Descriptor() ([]byte, []int) {
+	return file_api_web_v1_web_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *PutModuleResponse) GetModule() *ModuleInfo {
+	if x != nil {
+		return x.Module
+	}
+	return nil
+}
+
+// ModuleInfo message represents a stored version of a dependency module.
+type ModuleInfo struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Version       string                 `protobuf:"bytes,2,opt,name=version,proto3" json:"version,omitempty"`
+	Files         int32                  `protobuf:"varint,3,opt,name=files,proto3" json:"files,omitempty"` // Number of files of the module
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ModuleInfo) Reset() {
+	*x = ModuleInfo{}
+	mi := &file_api_web_v1_web_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ModuleInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ModuleInfo) ProtoMessage() {}
+
+func (x *ModuleInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_api_web_v1_web_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ModuleInfo.ProtoReflect.Descriptor instead.
+func (*ModuleInfo) Descriptor() ([]byte, []int) {
+	return file_api_web_v1_web_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *ModuleInfo) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *ModuleInfo) GetVersion() string {
+	if x != nil {
+		return x.Version
+	}
+	return ""
+}
+
+func (x *ModuleInfo) GetFiles() int32 {
+	if x != nil {
+		return x.Files
+	}
+	return 0
+}
+
+func (x *ModuleInfo) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
 // PluginInfo message represents information about a plugin.
 type PluginInfo struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`                                // Unique identifier for the plugin
-	Group         string                 `protobuf:"bytes,2,opt,name=group,proto3" json:"group,omitempty"`                          // Group to which the plugin belongs
-	Name          string                 `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`                            // Name of the plugin
-	Version       string                 `protobuf:"bytes,4,opt,name=version,proto3" json:"version,omitempty"`                      // Version of the plugin
-	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"` // Timestamp when the plugin was installed
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	Id               string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`                                                      // Unique identifier for the plugin
+	Group            string                 `protobuf:"bytes,2,opt,name=group,proto3" json:"group,omitempty"`                                                // Group to which the plugin belongs
+	Name             string                 `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`                                                  // Name of the plugin
+	Version          string                 `protobuf:"bytes,4,opt,name=version,proto3" json:"version,omitempty"`                                            // Version of the plugin
+	CreatedAt        *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`                       // Timestamp when the plugin was installed
+	ImageStatus      string                 `protobuf:"bytes,6,opt,name=image_status,json=imageStatus,proto3" json:"image_status,omitempty"`                 // Pre-pull state of the image: pending, pulling, ready, failed or skipped
+	ImageError       string                 `protobuf:"bytes,7,opt,name=image_error,json=imageError,proto3" json:"image_error,omitempty"`                    // Last pull error of the image
+	Image            string                 `protobuf:"bytes,8,opt,name=image,proto3" json:"image,omitempty"`                                                // Resolved image reference of the plugin
+	Registry         string                 `protobuf:"bytes,9,opt,name=registry,proto3" json:"registry,omitempty"`                                          // Upstream registry of the image, empty for the default one
+	Description      string                 `protobuf:"bytes,10,opt,name=description,proto3" json:"description,omitempty"`                                   // Description from the tech.easyp.plugin.description label
+	Source           string                 `protobuf:"bytes,11,opt,name=source,proto3" json:"source,omitempty"`                                             // Upstream source of the plugin from the tech.easyp.plugin.source label
+	License          string                 `protobuf:"bytes,12,opt,name=license,proto3" json:"license,omitempty"`                                           // License from the tech.easyp.plugin.license label
+	Options          []*PluginOption        `protobuf:"bytes,13,rep,name=options,proto3" json:"options,omitempty"`                                           // Options accepted in the generation parameter
+	Compatibility    *PluginCompatibility   `protobuf:"bytes,14,opt,name=compatibility,proto3" json:"compatibility,omitempty"`                               // Declared compatibility constraints
+	PostProcess      *PluginPostProcess     `protobuf:"bytes,15,opt,name=post_process,json=postProcess,proto3" json:"post_process,omitempty"`                // Post-processing of the generated files
+	DeterminismBadge string                 `protobuf:"bytes,16,opt,name=determinism_badge,json=determinismBadge,proto3" json:"determinism_badge,omitempty"` // Last determinism check: deterministic, nondeterministic or error, empty if unchecked
+	Determinism      *PluginDeterminism     `protobuf:"bytes,17,opt,name=determinism,proto3" json:"determinism,omitempty"`                                   // Details of the last determinism check
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
 }
 
 func (x *PluginInfo) Reset() {
 	*x = PluginInfo{}
-	mi := &file_api_web_v1_web_proto_msgTypes[2]
+	mi := &file_api_web_v1_web_proto_msgTypes[26]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -129,7 +1405,7 @@ func (x *PluginInfo) String() string {
 func (*PluginInfo) ProtoMessage() {}
 
 func (x *PluginInfo) ProtoReflect() protoreflect.Message {
-	mi := &file_api_web_v1_web_proto_msgTypes[2]
+	mi := &file_api_web_v1_web_proto_msgTypes[26]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -142,7 +1418,7 @@ func (x *PluginInfo) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use PluginInfo.ProtoReflect.Descriptor instead.
 func (*PluginInfo) Descriptor() ([]byte, []int) {
-	return file_api_web_v1_web_proto_rawDescGZIP(), []int{2}
+	return file_api_web_v1_web_proto_rawDescGZIP(), []int{26}
 }
 
 func (x *PluginInfo) GetId() string {
@@ -180,15 +1456,461 @@ func (x *PluginInfo) GetCreatedAt() *timestamppb.Timestamp {
 	return nil
 }
 
+func (x *PluginInfo) GetImageStatus() string {
+	if x != nil {
+		return x.ImageStatus
+	}
+	return ""
+}
+
+func (x *PluginInfo) GetImageError() string {
+	if x != nil {
+		return x.ImageError
+	}
+	return ""
+}
+
+func (x *PluginInfo) GetImage() string {
+	if x != nil {
+		return x.Image
+	}
+	return ""
+}
+
+func (x *PluginInfo) GetRegistry() string {
+	if x != nil {
+		return x.Registry
+	}
+	return ""
+}
+
+func (x *PluginInfo) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *PluginInfo) GetSource() string {
+	if x != nil {
+		return x.Source
+	}
+	return ""
+}
+
+func (x *PluginInfo) GetLicense() string {
+	if x != nil {
+		return x.License
+	}
+	return ""
+}
+
+func (x *PluginInfo) GetOptions() []*PluginOption {
+	if x != nil {
+		return x.Options
+	}
+	return nil
+}
+
+func (x *PluginInfo) GetCompatibility() *PluginCompatibility {
+	if x != nil {
+		return x.Compatibility
+	}
+	return nil
+}
+
+func (x *PluginInfo) GetPostProcess() *PluginPostProcess {
+	if x != nil {
+		return x.PostProcess
+	}
+	return nil
+}
+
+func (x *PluginInfo) GetDeterminismBadge() string {
+	if x != nil {
+		return x.DeterminismBadge
+	}
+	return ""
+}
+
+func (x *PluginInfo) GetDeterminism() *PluginDeterminism {
+	if x != nil {
+		return x.Determinism
+	}
+	return nil
+}
+
+// PluginDeterminism message represents the last run of a plugin twice on the same request.
+type PluginDeterminism struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Differences   []string               `protobuf:"bytes,1,rep,name=differences,proto3" json:"differences,omitempty"` // Files whose contents differed between the runs
+	Error         string                 `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`             // Error of a run
+	Source        string                 `protobuf:"bytes,3,opt,name=source,proto3" json:"source,omitempty"`           // request or background
+	CheckedAt     *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=checked_at,json=checkedAt,proto3" json:"checked_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PluginDeterminism) Reset() {
+	*x = PluginDeterminism{}
+	mi := &file_api_web_v1_web_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PluginDeterminism) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PluginDeterminism) ProtoMessage() {}
+
+func (x *PluginDeterminism) ProtoReflect() protoreflect.Message {
+	mi := &file_api_web_v1_web_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PluginDeterminism.ProtoReflect.Descriptor instead.
+func (*PluginDeterminism) Descriptor() ([]byte, []int) {
+	return file_api_web_v1_web_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *PluginDeterminism) GetDifferences() []string {
+	if x != nil {
+		return x.Differences
+	}
+	return nil
+}
+
+func (x *PluginDeterminism) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *PluginDeterminism) GetSource() string {
+	if x != nil {
+		return x.Source
+	}
+	return ""
+}
+
+func (x *PluginDeterminism) GetCheckedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CheckedAt
+	}
+	return nil
+}
+
+// PluginPostProcess message represents steps applied to the generated files.
+type PluginPostProcess struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Header        string                 `protobuf:"bytes,1,opt,name=header,proto3" json:"header,omitempty"`                           // Prepended to every generated .go file
+	PathPrefix    string                 `protobuf:"bytes,2,opt,name=path_prefix,json=pathPrefix,proto3" json:"path_prefix,omitempty"` // Prepended to names of the generated files
+	Include       []string               `protobuf:"bytes,3,rep,name=include,proto3" json:"include,omitempty"`                         // Globs of the files to keep
+	Exclude       []string               `protobuf:"bytes,4,rep,name=exclude,proto3" json:"exclude,omitempty"`                         // Globs of the files to drop
+	GoFormat      bool                   `protobuf:"varint,5,opt,name=go_format,json=goFormat,proto3" json:"go_format,omitempty"`      // Format .go files with go/format
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PluginPostProcess) Reset() {
+	*x = PluginPostProcess{}
+	mi := &file_api_web_v1_web_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PluginPostProcess) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PluginPostProcess) ProtoMessage() {}
+
+func (x *PluginPostProcess) ProtoReflect() protoreflect.Message {
+	mi := &file_api_web_v1_web_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PluginPostProcess.ProtoReflect.Descriptor instead.
+func (*PluginPostProcess) Descriptor() ([]byte, []int) {
+	return file_api_web_v1_web_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *PluginPostProcess) GetHeader() string {
+	if x != nil {
+		return x.Header
+	}
+	return ""
+}
+
+func (x *PluginPostProcess) GetPathPrefix() string {
+	if x != nil {
+		return x.PathPrefix
+	}
+	return ""
+}
+
+func (x *PluginPostProcess) GetInclude() []string {
+	if x != nil {
+		return x.Include
+	}
+	return nil
+}
+
+func (x *PluginPostProcess) GetExclude() []string {
+	if x != nil {
+		return x.Exclude
+	}
+	return nil
+}
+
+func (x *PluginPostProcess) GetGoFormat() bool {
+	if x != nil {
+		return x.GoFormat
+	}
+	return false
+}
+
+// PluginCompatibility message represents constraints on the compiler and the plugins generated together.
+type PluginCompatibility struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	CompilerVersion string                 `protobuf:"bytes,1,opt,name=compiler_version,json=compilerVersion,proto3" json:"compiler_version,omitempty"`                                    // Range of the compiler version, e.g. ">=3.21.0"
+	Plugins         map[string]string      `protobuf:"bytes,2,rep,name=plugins,proto3" json:"plugins,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"` // Version ranges by "<group>/<name>"
+	Mode            string                 `protobuf:"bytes,3,opt,name=mode,proto3" json:"mode,omitempty"`                                                                                 // warn or fail
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *PluginCompatibility) Reset() {
+	*x = PluginCompatibility{}
+	mi := &file_api_web_v1_web_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PluginCompatibility) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PluginCompatibility) ProtoMessage() {}
+
+func (x *PluginCompatibility) ProtoReflect() protoreflect.Message {
+	mi := &file_api_web_v1_web_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PluginCompatibility.ProtoReflect.Descriptor instead.
+func (*PluginCompatibility) Descriptor() ([]byte, []int) {
+	return file_api_web_v1_web_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *PluginCompatibility) GetCompilerVersion() string {
+	if x != nil {
+		return x.CompilerVersion
+	}
+	return ""
+}
+
+func (x *PluginCompatibility) GetPlugins() map[string]string {
+	if x != nil {
+		return x.Plugins
+	}
+	return nil
+}
+
+func (x *PluginCompatibility) GetMode() string {
+	if x != nil {
+		return x.Mode
+	}
+	return ""
+}
+
+// PluginOption message represents an option accepted by a plugin.
+type PluginOption struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"` // Name of the option, e.g. "paths", a trailing * matches any suffix
+	Description   string                 `protobuf:"bytes,2,opt,name=description,proto3" json:"description,omitempty"`
+	Type          string                 `protobuf:"bytes,3,opt,name=type,proto3" json:"type,omitempty"`       // string, bool or int
+	Values        []string               `protobuf:"bytes,4,rep,name=values,proto3" json:"values,omitempty"`   // Allowed values, any value of the type when empty
+	Default       string                 `protobuf:"bytes,5,opt,name=default,proto3" json:"default,omitempty"` // Default value used by the plugin
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PluginOption) Reset() {
+	*x = PluginOption{}
+	mi := &file_api_web_v1_web_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PluginOption) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PluginOption) ProtoMessage() {}
+
+func (x *PluginOption) ProtoReflect() protoreflect.Message {
+	mi := &file_api_web_v1_web_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PluginOption.ProtoReflect.Descriptor instead.
+func (*PluginOption) Descriptor() ([]byte, []int) {
+	return file_api_web_v1_web_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *PluginOption) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *PluginOption) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *PluginOption) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *PluginOption) GetValues() []string {
+	if x != nil {
+		return x.Values
+	}
+	return nil
+}
+
+func (x *PluginOption) GetDefault() string {
+	if x != nil {
+		return x.Default
+	}
+	return ""
+}
+
 var File_api_web_v1_web_proto protoreflect.FileDescriptor
 
 const file_api_web_v1_web_proto_rawDesc = "" +
 	"\n" +
 	"\x14api/web/v1/web.proto\x12\n" +
-	"api.web.v1\x1a\x1cgoogle/api/annotations.proto\x1a\x1fgoogle/protobuf/timestamp.proto\"\x10\n" +
+	"api.web.v1\x1a\x1cgoogle/api/annotations.proto\x1a google/protobuf/descriptor.proto\x1a\x1cgoogle/protobuf/struct.proto\x1a\x1fgoogle/protobuf/timestamp.proto\"\x10\n" +
 	"\x0ePluginsRequest\"C\n" +
 	"\x0fPluginsResponse\x120\n" +
-	"\aplugins\x18\x01 \x03(\v2\x16.api.web.v1.PluginInfoR\aplugins\"\x9b\x01\n" +
+	"\aplugins\x18\x01 \x03(\v2\x16.api.web.v1.PluginInfoR\aplugins\"\xe7\x01\n" +
+	"\x15RegisterPluginRequest\x12\x14\n" +
+	"\x05group\x18\x01 \x01(\tR\x05group\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x18\n" +
+	"\aversion\x18\x03 \x01(\tR\aversion\x12/\n" +
+	"\x06config\x18\x04 \x01(\v2\x17.google.protobuf.StructR\x06config\x12\x14\n" +
+	"\x05image\x18\x05 \x01(\tR\x05image\x12\x1a\n" +
+	"\bregistry\x18\x06 \x01(\tR\bregistry\x12'\n" +
+	"\x0frun_conformance\x18\a \x01(\bR\x0erunConformance\"\xb6\x01\n" +
+	"\x16RegisterPluginResponse\x12.\n" +
+	"\x06plugin\x18\x01 \x01(\v2\x16.api.web.v1.PluginInfoR\x06plugin\x12?\n" +
+	"\vconformance\x18\x02 \x01(\v2\x1d.api.web.v1.ConformanceReportR\vconformance\x12+\n" +
+	"\x11conformance_error\x18\x03 \x01(\tR\x10conformanceError\"/\n" +
+	"\x15RunConformanceRequest\x12\x16\n" +
+	"\x06plugin\x18\x01 \x01(\tR\x06plugin\"O\n" +
+	"\x16RunConformanceResponse\x125\n" +
+	"\x06report\x18\x01 \x01(\v2\x1d.api.web.v1.ConformanceReportR\x06report\"\x8e\x01\n" +
+	"\x11ConformanceReport\x12\x16\n" +
+	"\x06plugin\x18\x01 \x01(\tR\x06plugin\x121\n" +
+	"\x05cases\x18\x02 \x03(\v2\x1b.api.web.v1.ConformanceCaseR\x05cases\x12\x16\n" +
+	"\x06passed\x18\x03 \x01(\x05R\x06passed\x12\x16\n" +
+	"\x06failed\x18\x04 \x01(\x05R\x06failed\"\x8a\x01\n" +
+	"\x0fConformanceCase\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x16\n" +
+	"\x06passed\x18\x02 \x01(\bR\x06passed\x12\x14\n" +
+	"\x05error\x18\x03 \x01(\tR\x05error\x125\n" +
+	"\x05files\x18\x04 \x03(\v2\x1f.api.web.v1.ConformanceFileDiffR\x05files\"U\n" +
+	"\x13ConformanceFileDiff\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x16\n" +
+	"\x06status\x18\x02 \x01(\tR\x06status\x12\x12\n" +
+	"\x04diff\x18\x03 \x01(\tR\x04diff\"\x10\n" +
+	"\x0eSecretsRequest\"C\n" +
+	"\x0fSecretsResponse\x120\n" +
+	"\asecrets\x18\x01 \x03(\v2\x16.api.web.v1.SecretInfoR\asecrets\"A\n" +
+	"\x10PutSecretRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x19\n" +
+	"\x05value\x18\x02 \x01(\tB\x03\x80\x01\x01R\x05value\"C\n" +
+	"\x11PutSecretResponse\x12.\n" +
+	"\x06secret\x18\x01 \x01(\v2\x16.api.web.v1.SecretInfoR\x06secret\")\n" +
+	"\x13DeleteSecretRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\"\x16\n" +
+	"\x14DeleteSecretResponse\"e\n" +
+	"\x13AddSignatureRequest\x12\x16\n" +
+	"\x06digest\x18\x01 \x01(\tR\x06digest\x12\x18\n" +
+	"\apayload\x18\x02 \x01(\fR\apayload\x12\x1c\n" +
+	"\tsignature\x18\x03 \x01(\tR\tsignature\"\x16\n" +
+	"\x14AddSignatureResponse\"\x14\n" +
+	"\x12SyncCatalogRequest\"\x80\x02\n" +
+	"\x13SyncCatalogResponse\x120\n" +
+	"\acreated\x18\x01 \x03(\v2\x16.api.web.v1.PluginInfoR\acreated\x120\n" +
+	"\aupdated\x18\x02 \x03(\v2\x16.api.web.v1.PluginInfoR\aupdated\x12A\n" +
+	"\x10orphaned_plugins\x18\x03 \x03(\v2\x16.api.web.v1.PluginInfoR\x0forphanedPlugins\x12B\n" +
+	"\x0forphaned_images\x18\x04 \x03(\v2\x19.api.web.v1.OrphanedImageR\x0eorphanedImages\"=\n" +
+	"\rOrphanedImage\x12\x14\n" +
+	"\x05image\x18\x01 \x01(\tR\x05image\x12\x16\n" +
+	"\x06reason\x18\x02 \x01(\tR\x06reason\"\x96\x01\n" +
+	"\n" +
+	"SecretInfo\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x129\n" +
+	"\n" +
+	"created_at\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"updated_at\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\"\x10\n" +
+	"\x0eModulesRequest\"C\n" +
+	"\x0fModulesResponse\x120\n" +
+	"\amodules\x18\x01 \x03(\v2\x16.api.web.v1.ModuleInfoR\amodules\"\x8b\x01\n" +
+	"\x10PutModuleRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x18\n" +
+	"\aversion\x18\x02 \x01(\tR\aversion\x12I\n" +
+	"\x0edescriptor_set\x18\x03 \x01(\v2\".google.protobuf.FileDescriptorSetR\rdescriptorSet\"C\n" +
+	"\x11PutModuleResponse\x12.\n" +
+	"\x06module\x18\x01 \x01(\v2\x16.api.web.v1.ModuleInfoR\x06module\"\x8b\x01\n" +
+	"\n" +
+	"ModuleInfo\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x18\n" +
+	"\aversion\x18\x02 \x01(\tR\aversion\x12\x14\n" +
+	"\x05files\x18\x03 \x01(\x05R\x05files\x129\n" +
+	"\n" +
+	"created_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\"\x90\x05\n" +
 	"\n" +
 	"PluginInfo\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x14\n" +
@@ -196,10 +1918,59 @@ const file_api_web_v1_web_proto_rawDesc = "" +
 	"\x04name\x18\x03 \x01(\tR\x04name\x12\x18\n" +
 	"\aversion\x18\x04 \x01(\tR\aversion\x129\n" +
 	"\n" +
-	"created_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt2e\n" +
+	"created_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x12!\n" +
+	"\fimage_status\x18\x06 \x01(\tR\vimageStatus\x12\x1f\n" +
+	"\vimage_error\x18\a \x01(\tR\n" +
+	"imageError\x12\x14\n" +
+	"\x05image\x18\b \x01(\tR\x05image\x12\x1a\n" +
+	"\bregistry\x18\t \x01(\tR\bregistry\x12 \n" +
+	"\vdescription\x18\n" +
+	" \x01(\tR\vdescription\x12\x16\n" +
+	"\x06source\x18\v \x01(\tR\x06source\x12\x18\n" +
+	"\alicense\x18\f \x01(\tR\alicense\x122\n" +
+	"\aoptions\x18\r \x03(\v2\x18.api.web.v1.PluginOptionR\aoptions\x12E\n" +
+	"\rcompatibility\x18\x0e \x01(\v2\x1f.api.web.v1.PluginCompatibilityR\rcompatibility\x12@\n" +
+	"\fpost_process\x18\x0f \x01(\v2\x1d.api.web.v1.PluginPostProcessR\vpostProcess\x12+\n" +
+	"\x11determinism_badge\x18\x10 \x01(\tR\x10determinismBadge\x12?\n" +
+	"\vdeterminism\x18\x11 \x01(\v2\x1d.api.web.v1.PluginDeterminismR\vdeterminism\"\x9e\x01\n" +
+	"\x11PluginDeterminism\x12 \n" +
+	"\vdifferences\x18\x01 \x03(\tR\vdifferences\x12\x14\n" +
+	"\x05error\x18\x02 \x01(\tR\x05error\x12\x16\n" +
+	"\x06source\x18\x03 \x01(\tR\x06source\x129\n" +
+	"\n" +
+	"checked_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\tcheckedAt\"\x9d\x01\n" +
+	"\x11PluginPostProcess\x12\x16\n" +
+	"\x06header\x18\x01 \x01(\tR\x06header\x12\x1f\n" +
+	"\vpath_prefix\x18\x02 \x01(\tR\n" +
+	"pathPrefix\x12\x18\n" +
+	"\ainclude\x18\x03 \x03(\tR\ainclude\x12\x18\n" +
+	"\aexclude\x18\x04 \x03(\tR\aexclude\x12\x1b\n" +
+	"\tgo_format\x18\x05 \x01(\bR\bgoFormat\"\xd8\x01\n" +
+	"\x13PluginCompatibility\x12)\n" +
+	"\x10compiler_version\x18\x01 \x01(\tR\x0fcompilerVersion\x12F\n" +
+	"\aplugins\x18\x02 \x03(\v2,.api.web.v1.PluginCompatibility.PluginsEntryR\aplugins\x12\x12\n" +
+	"\x04mode\x18\x03 \x01(\tR\x04mode\x1a:\n" +
+	"\fPluginsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\x8a\x01\n" +
+	"\fPluginOption\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12 \n" +
+	"\vdescription\x18\x02 \x01(\tR\vdescription\x12\x12\n" +
+	"\x04type\x18\x03 \x01(\tR\x04type\x12\x16\n" +
+	"\x06values\x18\x04 \x03(\tR\x06values\x12\x18\n" +
+	"\adefault\x18\x05 \x01(\tR\adefault2\x92\b\n" +
 	"\n" +
 	"ServiceAPI\x12W\n" +
-	"\aPlugins\x12\x1a.api.web.v1.PluginsRequest\x1a\x1b.api.web.v1.PluginsResponse\"\x13\x82\xd3\xe4\x93\x02\r\x12\v/v1/pluginsB.Z,github.com/easyp-tech/service/api/web/v1;webb\x06proto3"
+	"\aPlugins\x12\x1a.api.web.v1.PluginsRequest\x1a\x1b.api.web.v1.PluginsResponse\"\x13\x82\xd3\xe4\x93\x02\r\x12\v/v1/plugins\x12o\n" +
+	"\x0eRegisterPlugin\x12!.api.web.v1.RegisterPluginRequest\x1a\".api.web.v1.RegisterPluginResponse\"\x16\x82\xd3\xe4\x93\x02\x10:\x01*\"\v/v1/plugins\x12s\n" +
+	"\x0eRunConformance\x12!.api.web.v1.RunConformanceRequest\x1a\".api.web.v1.RunConformanceResponse\"\x1a\x82\xd3\xe4\x93\x02\x14:\x01*\"\x0f/v1/conformance\x12W\n" +
+	"\aSecrets\x12\x1a.api.web.v1.SecretsRequest\x1a\x1b.api.web.v1.SecretsResponse\"\x13\x82\xd3\xe4\x93\x02\r\x12\v/v1/secrets\x12g\n" +
+	"\tPutSecret\x12\x1c.api.web.v1.PutSecretRequest\x1a\x1d.api.web.v1.PutSecretResponse\"\x1d\x82\xd3\xe4\x93\x02\x17:\x01*\x1a\x12/v1/secrets/{name}\x12m\n" +
+	"\fDeleteSecret\x12\x1f.api.web.v1.DeleteSecretRequest\x1a .api.web.v1.DeleteSecretResponse\"\x1a\x82\xd3\xe4\x93\x02\x14*\x12/v1/secrets/{name}\x12l\n" +
+	"\fAddSignature\x12\x1f.api.web.v1.AddSignatureRequest\x1a .api.web.v1.AddSignatureResponse\"\x19\x82\xd3\xe4\x93\x02\x13:\x01*\"\x0e/v1/signatures\x12W\n" +
+	"\aModules\x12\x1a.api.web.v1.ModulesRequest\x1a\x1b.api.web.v1.ModulesResponse\"\x13\x82\xd3\xe4\x93\x02\r\x12\v/v1/modules\x12`\n" +
+	"\tPutModule\x12\x1c.api.web.v1.PutModuleRequest\x1a\x1d.api.web.v1.PutModuleResponse\"\x16\x82\xd3\xe4\x93\x02\x10:\x01*\"\v/v1/modules\x12k\n" +
+	"\vSyncCatalog\x12\x1e.api.web.v1.SyncCatalogRequest\x1a\x1f.api.web.v1.SyncCatalogResponse\"\x1b\x82\xd3\xe4\x93\x02\x15:\x01*\"\x10/v1/catalog:syncB.Z,github.com/easyp-tech/service/api/web/v1;webb\x06proto3"
 
 var (
 	file_api_web_v1_web_proto_rawDescOnce sync.Once
@@ -213,23 +1984,96 @@ func file_api_web_v1_web_proto_rawDescGZIP() []byte {
 	return file_api_web_v1_web_proto_rawDescData
 }
 
-var file_api_web_v1_web_proto_msgTypes = make([]protoimpl.MessageInfo, 3)
+var file_api_web_v1_web_proto_msgTypes = make([]protoimpl.MessageInfo, 32)
 var file_api_web_v1_web_proto_goTypes = []any{
-	(*PluginsRequest)(nil),        // 0: api.web.v1.PluginsRequest
-	(*PluginsResponse)(nil),       // 1: api.web.v1.PluginsResponse
-	(*PluginInfo)(nil),            // 2: api.web.v1.PluginInfo
-	(*timestamppb.Timestamp)(nil), // 3: google.protobuf.Timestamp
+	(*PluginsRequest)(nil),                 // 0: api.web.v1.PluginsRequest
+	(*PluginsResponse)(nil),                // 1: api.web.v1.PluginsResponse
+	(*RegisterPluginRequest)(nil),          // 2: api.web.v1.RegisterPluginRequest
+	(*RegisterPluginResponse)(nil),         // 3: api.web.v1.RegisterPluginResponse
+	(*RunConformanceRequest)(nil),          // 4: api.web.v1.RunConformanceRequest
+	(*RunConformanceResponse)(nil),         // 5: api.web.v1.RunConformanceResponse
+	(*ConformanceReport)(nil),              // 6: api.web.v1.ConformanceReport
+	(*ConformanceCase)(nil),                // 7: api.web.v1.ConformanceCase
+	(*ConformanceFileDiff)(nil),            // 8: api.web.v1.ConformanceFileDiff
+	(*SecretsRequest)(nil),                 // 9: api.web.v1.SecretsRequest
+	(*SecretsResponse)(nil),                // 10: api.web.v1.SecretsResponse
+	(*PutSecretRequest)(nil),               // 11: api.web.v1.PutSecretRequest
+	(*PutSecretResponse)(nil),              // 12: api.web.v1.PutSecretResponse
+	(*DeleteSecretRequest)(nil),            // 13: api.web.v1.DeleteSecretRequest
+	(*DeleteSecretResponse)(nil),           // 14: api.web.v1.DeleteSecretResponse
+	(*AddSignatureRequest)(nil),            // 15: api.web.v1.AddSignatureRequest
+	(*AddSignatureResponse)(nil),           // 16: api.web.v1.AddSignatureResponse
+	(*SyncCatalogRequest)(nil),             // 17: api.web.v1.SyncCatalogRequest
+	(*SyncCatalogResponse)(nil),            // 18: api.web.v1.SyncCatalogResponse
+	(*OrphanedImage)(nil),                  // 19: api.web.v1.OrphanedImage
+	(*SecretInfo)(nil),                     // 20: api.web.v1.SecretInfo
+	(*ModulesRequest)(nil),                 // 21: api.web.v1.ModulesRequest
+	(*ModulesResponse)(nil),                // 22: api.web.v1.ModulesResponse
+	(*PutModuleRequest)(nil),               // 23: api.web.v1.PutModuleRequest
+	(*PutModuleResponse)(nil),              // 24: api.web.v1.PutModuleResponse
+	(*ModuleInfo)(nil),                     // 25: api.web.v1.ModuleInfo
+	(*PluginInfo)(nil),                     // 26: api.web.v1.PluginInfo
+	(*PluginDeterminism)(nil),              // 27: api.web.v1.PluginDeterminism
+	(*PluginPostProcess)(nil),              // 28: api.web.v1.PluginPostProcess
+	(*PluginCompatibility)(nil),            // 29: api.web.v1.PluginCompatibility
+	(*PluginOption)(nil),                   // 30: api.web.v1.PluginOption
+	nil,                                    // 31: api.web.v1.PluginCompatibility.PluginsEntry
+	(*structpb.Struct)(nil),                // 32: google.protobuf.Struct
+	(*timestamppb.Timestamp)(nil),          // 33: google.protobuf.Timestamp
+	(*descriptorpb.FileDescriptorSet)(nil), // 34: google.protobuf.FileDescriptorSet
 }
 var file_api_web_v1_web_proto_depIdxs = []int32{
-	2, // 0: api.web.v1.PluginsResponse.plugins:type_name -> api.web.v1.PluginInfo
-	3, // 1: api.web.v1.PluginInfo.created_at:type_name -> google.protobuf.Timestamp
-	0, // 2: api.web.v1.ServiceAPI.Plugins:input_type -> api.web.v1.PluginsRequest
-	1, // 3: api.web.v1.ServiceAPI.Plugins:output_type -> api.web.v1.PluginsResponse
-	3, // [3:4] is the sub-list for method output_type
-	2, // [2:3] is the sub-list for method input_type
-	2, // [2:2] is the sub-list for extension type_name
-	2, // [2:2] is the sub-list for extension extendee
-	0, // [0:2] is the sub-list for field type_name
+	26, // 0: api.web.v1.PluginsResponse.plugins:type_name -> api.web.v1.PluginInfo
+	32, // 1: api.web.v1.RegisterPluginRequest.config:type_name -> google.protobuf.Struct
+	26, // 2: api.web.v1.RegisterPluginResponse.plugin:type_name -> api.web.v1.PluginInfo
+	6,  // 3: api.web.v1.RegisterPluginResponse.conformance:type_name -> api.web.v1.ConformanceReport
+	6,  // 4: api.web.v1.RunConformanceResponse.report:type_name -> api.web.v1.ConformanceReport
+	7,  // 5: api.web.v1.ConformanceReport.cases:type_name -> api.web.v1.ConformanceCase
+	8,  // 6: api.web.v1.ConformanceCase.files:type_name -> api.web.v1.ConformanceFileDiff
+	20, // 7: api.web.v1.SecretsResponse.secrets:type_name -> api.web.v1.SecretInfo
+	20, // 8: api.web.v1.PutSecretResponse.secret:type_name -> api.web.v1.SecretInfo
+	26, // 9: api.web.v1.SyncCatalogResponse.created:type_name -> api.web.v1.PluginInfo
+	26, // 10: api.web.v1.SyncCatalogResponse.updated:type_name -> api.web.v1.PluginInfo
+	26, // 11: api.web.v1.SyncCatalogResponse.orphaned_plugins:type_name -> api.web.v1.PluginInfo
+	19, // 12: api.web.v1.SyncCatalogResponse.orphaned_images:type_name -> api.web.v1.OrphanedImage
+	33, // 13: api.web.v1.SecretInfo.created_at:type_name -> google.protobuf.Timestamp
+	33, // 14: api.web.v1.SecretInfo.updated_at:type_name -> google.protobuf.Timestamp
+	25, // 15: api.web.v1.ModulesResponse.modules:type_name -> api.web.v1.ModuleInfo
+	34, // 16: api.web.v1.PutModuleRequest.descriptor_set:type_name -> google.protobuf.FileDescriptorSet
+	25, // 17: api.web.v1.PutModuleResponse.module:type_name -> api.web.v1.ModuleInfo
+	33, // 18: api.web.v1.ModuleInfo.created_at:type_name -> google.protobuf.Timestamp
+	33, // 19: api.web.v1.PluginInfo.created_at:type_name -> google.protobuf.Timestamp
+	30, // 20: api.web.v1.PluginInfo.options:type_name -> api.web.v1.PluginOption
+	29, // 21: api.web.v1.PluginInfo.compatibility:type_name -> api.web.v1.PluginCompatibility
+	28, // 22: api.web.v1.PluginInfo.post_process:type_name -> api.web.v1.PluginPostProcess
+	27, // 23: api.web.v1.PluginInfo.determinism:type_name -> api.web.v1.PluginDeterminism
+	33, // 24: api.web.v1.PluginDeterminism.checked_at:type_name -> google.protobuf.Timestamp
+	31, // 25: api.web.v1.PluginCompatibility.plugins:type_name -> api.web.v1.PluginCompatibility.PluginsEntry
+	0,  // 26: api.web.v1.ServiceAPI.Plugins:input_type -> api.web.v1.PluginsRequest
+	2,  // 27: api.web.v1.ServiceAPI.RegisterPlugin:input_type -> api.web.v1.RegisterPluginRequest
+	4,  // 28: api.web.v1.ServiceAPI.RunConformance:input_type -> api.web.v1.RunConformanceRequest
+	9,  // 29: api.web.v1.ServiceAPI.Secrets:input_type -> api.web.v1.SecretsRequest
+	11, // 30: api.web.v1.ServiceAPI.PutSecret:input_type -> api.web.v1.PutSecretRequest
+	13, // 31: api.web.v1.ServiceAPI.DeleteSecret:input_type -> api.web.v1.DeleteSecretRequest
+	15, // 32: api.web.v1.ServiceAPI.AddSignature:input_type -> api.web.v1.AddSignatureRequest
+	21, // 33: api.web.v1.ServiceAPI.Modules:input_type -> api.web.v1.ModulesRequest
+	23, // 34: api.web.v1.ServiceAPI.PutModule:input_type -> api.web.v1.PutModuleRequest
+	17, // 35: api.web.v1.ServiceAPI.SyncCatalog:input_type -> api.web.v1.SyncCatalogRequest
+	1,  // 36: api.web.v1.ServiceAPI.Plugins:output_type -> api.web.v1.PluginsResponse
+	3,  // 37: api.web.v1.ServiceAPI.RegisterPlugin:output_type -> api.web.v1.RegisterPluginResponse
+	5,  // 38: api.web.v1.ServiceAPI.RunConformance:output_type -> api.web.v1.RunConformanceResponse
+	10, // 39: api.web.v1.ServiceAPI.Secrets:output_type -> api.web.v1.SecretsResponse
+	12, // 40: api.web.v1.ServiceAPI.PutSecret:output_type -> api.web.v1.PutSecretResponse
+	14, // 41: api.web.v1.ServiceAPI.DeleteSecret:output_type -> api.web.v1.DeleteSecretResponse
+	16, // 42: api.web.v1.ServiceAPI.AddSignature:output_type -> api.web.v1.AddSignatureResponse
+	22, // 43: api.web.v1.ServiceAPI.Modules:output_type -> api.web.v1.ModulesResponse
+	24, // 44: api.web.v1.ServiceAPI.PutModule:output_type -> api.web.v1.PutModuleResponse
+	18, // 45: api.web.v1.ServiceAPI.SyncCatalog:output_type -> api.web.v1.SyncCatalogResponse
+	36, // [36:46] is the sub-list for method output_type
+	26, // [26:36] is the sub-list for method input_type
+	26, // [26:26] is the sub-list for extension type_name
+	26, // [26:26] is the sub-list for extension extendee
+	0,  // [0:26] is the sub-list for field type_name
 }
 
 func init() { file_api_web_v1_web_proto_init() }
@@ -243,7 +2087,7 @@ func file_api_web_v1_web_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_api_web_v1_web_proto_rawDesc), len(file_api_web_v1_web_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   3,
+			NumMessages:   32,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
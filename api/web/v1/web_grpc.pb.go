@@ -19,7 +19,16 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	ServiceAPI_Plugins_FullMethodName = "/api.web.v1.ServiceAPI/Plugins"
+	ServiceAPI_Plugins_FullMethodName        = "/api.web.v1.ServiceAPI/Plugins"
+	ServiceAPI_RegisterPlugin_FullMethodName = "/api.web.v1.ServiceAPI/RegisterPlugin"
+	ServiceAPI_RunConformance_FullMethodName = "/api.web.v1.ServiceAPI/RunConformance"
+	ServiceAPI_Secrets_FullMethodName        = "/api.web.v1.ServiceAPI/Secrets"
+	ServiceAPI_PutSecret_FullMethodName      = "/api.web.v1.ServiceAPI/PutSecret"
+	ServiceAPI_DeleteSecret_FullMethodName   = "/api.web.v1.ServiceAPI/DeleteSecret"
+	ServiceAPI_AddSignature_FullMethodName   = "/api.web.v1.ServiceAPI/AddSignature"
+	ServiceAPI_Modules_FullMethodName        = "/api.web.v1.ServiceAPI/Modules"
+	ServiceAPI_PutModule_FullMethodName      = "/api.web.v1.ServiceAPI/PutModule"
+	ServiceAPI_SyncCatalog_FullMethodName    = "/api.web.v1.ServiceAPI/SyncCatalog"
 )
 
 // ServiceAPIClient is the client API for ServiceAPI service.
@@ -27,6 +36,26 @@ const (
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
 type ServiceAPIClient interface {
 	Plugins(ctx context.Context, in *PluginsRequest, opts ...grpc.CallOption) (*PluginsResponse, error)
+	// RegisterPlugin adds a plugin version to the registry.
+	// The config is validated the same way as before every run of the plugin.
+	RegisterPlugin(ctx context.Context, in *RegisterPluginRequest, opts ...grpc.CallOption) (*RegisterPluginResponse, error)
+	// RunConformance runs the fixtures of a plugin and compares the responses with the expected ones.
+	RunConformance(ctx context.Context, in *RunConformanceRequest, opts ...grpc.CallOption) (*RunConformanceResponse, error)
+	// Secrets returns names of the stored secrets, values are never returned.
+	Secrets(ctx context.Context, in *SecretsRequest, opts ...grpc.CallOption) (*SecretsResponse, error)
+	// PutSecret creates or replaces a secret which plugins reference by name.
+	PutSecret(ctx context.Context, in *PutSecretRequest, opts ...grpc.CallOption) (*PutSecretResponse, error)
+	DeleteSecret(ctx context.Context, in *DeleteSecretRequest, opts ...grpc.CallOption) (*DeleteSecretResponse, error)
+	// AddSignature stores a cosign signature of an image digest for registries
+	// which can't store signatures as OCI referrers.
+	AddSignature(ctx context.Context, in *AddSignatureRequest, opts ...grpc.CallOption) (*AddSignatureResponse, error)
+	// Modules returns the stored versions of dependency modules.
+	Modules(ctx context.Context, in *ModulesRequest, opts ...grpc.CallOption) (*ModulesResponse, error)
+	// PutModule stores a version of a dependency module, versions are immutable.
+	PutModule(ctx context.Context, in *PutModuleRequest, opts ...grpc.CallOption) (*PutModuleResponse, error)
+	// SyncCatalog registers images of the default registry catalog and reports
+	// orphaned plugins and images. Plugins are never removed.
+	SyncCatalog(ctx context.Context, in *SyncCatalogRequest, opts ...grpc.CallOption) (*SyncCatalogResponse, error)
 }
 
 type serviceAPIClient struct {
@@ -47,11 +76,121 @@ func (c *serviceAPIClient) Plugins(ctx context.Context, in *PluginsRequest, opts
 	return out, nil
 }
 
+func (c *serviceAPIClient) RegisterPlugin(ctx context.Context, in *RegisterPluginRequest, opts ...grpc.CallOption) (*RegisterPluginResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RegisterPluginResponse)
+	err := c.cc.Invoke(ctx, ServiceAPI_RegisterPlugin_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *serviceAPIClient) RunConformance(ctx context.Context, in *RunConformanceRequest, opts ...grpc.CallOption) (*RunConformanceResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RunConformanceResponse)
+	err := c.cc.Invoke(ctx, ServiceAPI_RunConformance_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *serviceAPIClient) Secrets(ctx context.Context, in *SecretsRequest, opts ...grpc.CallOption) (*SecretsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SecretsResponse)
+	err := c.cc.Invoke(ctx, ServiceAPI_Secrets_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *serviceAPIClient) PutSecret(ctx context.Context, in *PutSecretRequest, opts ...grpc.CallOption) (*PutSecretResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PutSecretResponse)
+	err := c.cc.Invoke(ctx, ServiceAPI_PutSecret_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *serviceAPIClient) DeleteSecret(ctx context.Context, in *DeleteSecretRequest, opts ...grpc.CallOption) (*DeleteSecretResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteSecretResponse)
+	err := c.cc.Invoke(ctx, ServiceAPI_DeleteSecret_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *serviceAPIClient) AddSignature(ctx context.Context, in *AddSignatureRequest, opts ...grpc.CallOption) (*AddSignatureResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AddSignatureResponse)
+	err := c.cc.Invoke(ctx, ServiceAPI_AddSignature_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *serviceAPIClient) Modules(ctx context.Context, in *ModulesRequest, opts ...grpc.CallOption) (*ModulesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ModulesResponse)
+	err := c.cc.Invoke(ctx, ServiceAPI_Modules_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *serviceAPIClient) PutModule(ctx context.Context, in *PutModuleRequest, opts ...grpc.CallOption) (*PutModuleResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PutModuleResponse)
+	err := c.cc.Invoke(ctx, ServiceAPI_PutModule_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *serviceAPIClient) SyncCatalog(ctx context.Context, in *SyncCatalogRequest, opts ...grpc.CallOption) (*SyncCatalogResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SyncCatalogResponse)
+	err := c.cc.Invoke(ctx, ServiceAPI_SyncCatalog_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // ServiceAPIServer is the server API for ServiceAPI service.
 // All implementations should embed UnimplementedServiceAPIServer
 // for forward compatibility.
 type ServiceAPIServer interface {
 	Plugins(context.Context, *PluginsRequest) (*PluginsResponse, error)
+	// RegisterPlugin adds a plugin version to the registry.
+	// The config is validated the same way as before every run of the plugin.
+	RegisterPlugin(context.Context, *RegisterPluginRequest) (*RegisterPluginResponse, error)
+	// RunConformance runs the fixtures of a plugin and compares the responses with the expected ones.
+	RunConformance(context.Context, *RunConformanceRequest) (*RunConformanceResponse, error)
+	// Secrets returns names of the stored secrets, values are never returned.
+	Secrets(context.Context, *SecretsRequest) (*SecretsResponse, error)
+	// PutSecret creates or replaces a secret which plugins reference by name.
+	PutSecret(context.Context, *PutSecretRequest) (*PutSecretResponse, error)
+	DeleteSecret(context.Context, *DeleteSecretRequest) (*DeleteSecretResponse, error)
+	// AddSignature stores a cosign signature of an image digest for registries
+	// which can't store signatures as OCI referrers.
+	AddSignature(context.Context, *AddSignatureRequest) (*AddSignatureResponse, error)
+	// Modules returns the stored versions of dependency modules.
+	Modules(context.Context, *ModulesRequest) (*ModulesResponse, error)
+	// PutModule stores a version of a dependency module, versions are immutable.
+	PutModule(context.Context, *PutModuleRequest) (*PutModuleResponse, error)
+	// SyncCatalog registers images of the default registry catalog and reports
+	// orphaned plugins and images. Plugins are never removed.
+	SyncCatalog(context.Context, *SyncCatalogRequest) (*SyncCatalogResponse, error)
 }
 
 // UnimplementedServiceAPIServer should be embedded to have
@@ -64,6 +203,33 @@ type UnimplementedServiceAPIServer struct{}
 func (UnimplementedServiceAPIServer) Plugins(context.Context, *PluginsRequest) (*PluginsResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Plugins not implemented")
 }
+func (UnimplementedServiceAPIServer) RegisterPlugin(context.Context, *RegisterPluginRequest) (*RegisterPluginResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RegisterPlugin not implemented")
+}
+func (UnimplementedServiceAPIServer) RunConformance(context.Context, *RunConformanceRequest) (*RunConformanceResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RunConformance not implemented")
+}
+func (UnimplementedServiceAPIServer) Secrets(context.Context, *SecretsRequest) (*SecretsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Secrets not implemented")
+}
+func (UnimplementedServiceAPIServer) PutSecret(context.Context, *PutSecretRequest) (*PutSecretResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PutSecret not implemented")
+}
+func (UnimplementedServiceAPIServer) DeleteSecret(context.Context, *DeleteSecretRequest) (*DeleteSecretResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteSecret not implemented")
+}
+func (UnimplementedServiceAPIServer) AddSignature(context.Context, *AddSignatureRequest) (*AddSignatureResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddSignature not implemented")
+}
+func (UnimplementedServiceAPIServer) Modules(context.Context, *ModulesRequest) (*ModulesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Modules not implemented")
+}
+func (UnimplementedServiceAPIServer) PutModule(context.Context, *PutModuleRequest) (*PutModuleResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PutModule not implemented")
+}
+func (UnimplementedServiceAPIServer) SyncCatalog(context.Context, *SyncCatalogRequest) (*SyncCatalogResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SyncCatalog not implemented")
+}
 func (UnimplementedServiceAPIServer) testEmbeddedByValue() {}
 
 // UnsafeServiceAPIServer may be embedded to opt out of forward compatibility for this service.
@@ -102,6 +268,168 @@ func _ServiceAPI_Plugins_Handler(srv interface{}, ctx context.Context, dec func(
 	return interceptor(ctx, in, info, handler)
 }
 
+func _ServiceAPI_RegisterPlugin_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RegisterPluginRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ServiceAPIServer).RegisterPlugin(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ServiceAPI_RegisterPlugin_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ServiceAPIServer).RegisterPlugin(ctx, req.(*RegisterPluginRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ServiceAPI_RunConformance_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RunConformanceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ServiceAPIServer).RunConformance(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ServiceAPI_RunConformance_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ServiceAPIServer).RunConformance(ctx, req.(*RunConformanceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ServiceAPI_Secrets_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SecretsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ServiceAPIServer).Secrets(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ServiceAPI_Secrets_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ServiceAPIServer).Secrets(ctx, req.(*SecretsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ServiceAPI_PutSecret_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PutSecretRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ServiceAPIServer).PutSecret(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ServiceAPI_PutSecret_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ServiceAPIServer).PutSecret(ctx, req.(*PutSecretRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ServiceAPI_DeleteSecret_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteSecretRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ServiceAPIServer).DeleteSecret(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ServiceAPI_DeleteSecret_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ServiceAPIServer).DeleteSecret(ctx, req.(*DeleteSecretRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ServiceAPI_AddSignature_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddSignatureRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ServiceAPIServer).AddSignature(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ServiceAPI_AddSignature_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ServiceAPIServer).AddSignature(ctx, req.(*AddSignatureRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ServiceAPI_Modules_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ModulesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ServiceAPIServer).Modules(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ServiceAPI_Modules_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ServiceAPIServer).Modules(ctx, req.(*ModulesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ServiceAPI_PutModule_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PutModuleRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ServiceAPIServer).PutModule(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ServiceAPI_PutModule_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ServiceAPIServer).PutModule(ctx, req.(*PutModuleRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ServiceAPI_SyncCatalog_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SyncCatalogRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ServiceAPIServer).SyncCatalog(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ServiceAPI_SyncCatalog_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ServiceAPIServer).SyncCatalog(ctx, req.(*SyncCatalogRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // ServiceAPI_ServiceDesc is the grpc.ServiceDesc for ServiceAPI service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -113,6 +441,42 @@ var ServiceAPI_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "Plugins",
 			Handler:    _ServiceAPI_Plugins_Handler,
 		},
+		{
+			MethodName: "RegisterPlugin",
+			Handler:    _ServiceAPI_RegisterPlugin_Handler,
+		},
+		{
+			MethodName: "RunConformance",
+			Handler:    _ServiceAPI_RunConformance_Handler,
+		},
+		{
+			MethodName: "Secrets",
+			Handler:    _ServiceAPI_Secrets_Handler,
+		},
+		{
+			MethodName: "PutSecret",
+			Handler:    _ServiceAPI_PutSecret_Handler,
+		},
+		{
+			MethodName: "DeleteSecret",
+			Handler:    _ServiceAPI_DeleteSecret_Handler,
+		},
+		{
+			MethodName: "AddSignature",
+			Handler:    _ServiceAPI_AddSignature_Handler,
+		},
+		{
+			MethodName: "Modules",
+			Handler:    _ServiceAPI_Modules_Handler,
+		},
+		{
+			MethodName: "PutModule",
+			Handler:    _ServiceAPI_PutModule_Handler,
+		},
+		{
+			MethodName: "SyncCatalog",
+			Handler:    _ServiceAPI_SyncCatalog_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "api/web/v1/web.proto",
@@ -2,12 +2,14 @@ package main
 
 import (
 	"context"
+	"errors"
 	"flag"
 	"fmt"
 	"log/slog"
 	"os"
 	"os/signal"
 	"path/filepath"
+	"slices"
 	"syscall"
 	"time"
 
@@ -21,6 +23,8 @@ import (
 	"github.com/sipki-tech/dev-platform/metrics"
 	"github.com/sipki-tech/dev-platform/serve"
 	"github.com/sipki-tech/dev-platform/version"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
 	"google.golang.org/grpc/grpclog"
 	"gopkg.in/yaml.v3"
 
@@ -38,18 +42,23 @@ const (
 
 type (
 	config struct {
-		Server   server         `yaml:"server" env:", prefix=SERVER_"`
-		DB       dbConfig       `yaml:"db" env:", prefix=DB_"`
-		Registry registryConfig `yaml:"registry" env:", prefix=REGISTRY_"`
+		Server      server            `yaml:"server" env:", prefix=SERVER_"`
+		DB          dbConfig          `yaml:"db" env:", prefix=DB_"`
+		Registry    registryConfig    `yaml:"registry" env:", prefix=REGISTRY_"`
+		Determinism determinismConfig `yaml:"determinism" env:", prefix=DETERMINISM_"`
+		Recorder    recorderConfig    `yaml:"recorder" env:", prefix=RECORDER_"`
 	}
 	server struct {
 		Host string `yaml:"host" env:"HOST, default=0.0.0.0"`
 		Port ports  `yaml:"port" env:", prefix=PORT_"`
+		// AdminToken is required by the calls which change the registry, they are disabled when empty.
+		AdminToken string `yaml:"admin_token" env:"ADMIN_TOKEN"`
 	}
 	ports struct {
 		GRPC   uint16 `yaml:"grpc" env:"GRPC, default=23410"`
 		Metric uint16 `yaml:"metric" env:"METRIC, default=23411"`
 		Health uint16 `yaml:"health" env:"HEALTH, default=23412"`
+		HTTP   uint16 `yaml:"http" env:"HTTP, default=23413"`
 	}
 	dbConfig struct {
 		MigrateDir string `yaml:"migrate_dir" env:"MIGRATE_DIR, default=migrate"`
@@ -57,18 +66,125 @@ type (
 		Postgres   string `yaml:"postgres" env:"POSTGRES_DSN"`
 	}
 	registryConfig struct {
-		Domain string `yaml:"domain" env:"DOMAIN, default=localhost:5005"`
+		Domain      string            `yaml:"domain" env:"DOMAIN, default=localhost:5005"`
+		Container   containerConfig   `yaml:"container" env:", prefix=CONTAINER_"`
+		Local       localConfig       `yaml:"local" env:", prefix=LOCAL_"`
+		Wasm        wasmConfig        `yaml:"wasm" env:", prefix=WASM_"`
+		Kubernetes  kubernetesConfig  `yaml:"kubernetes" env:", prefix=KUBERNETES_"`
+		Security    securityConfig    `yaml:"security" env:", prefix=SECURITY_"`
+		Limits      limitsConfig      `yaml:"limits" env:", prefix=LIMITS_"`
+		Secrets     secretsConfig     `yaml:"secrets" env:", prefix=SECRETS_"`
+		Images      imagesConfig      `yaml:"images" env:", prefix=IMAGES_"`
+		Catalog     catalogConfig     `yaml:"catalog" env:", prefix=CATALOG_"`
+		Modules     modulesConfig     `yaml:"modules" env:", prefix=MODULES_"`
+		Conformance conformanceConfig `yaml:"conformance" env:", prefix=CONFORMANCE_"`
+		// Groups can be set only in the config file.
+		Groups map[string]groupConfig `yaml:"groups"`
+		// Registries can be set only in the config file.
+		Registries map[string]upstreamConfig `yaml:"registries"`
+	}
+	containerConfig struct {
+		Engine string `yaml:"engine" env:"ENGINE, default=docker"`
+		Binary string `yaml:"binary" env:"BINARY"`
+	}
+	localConfig struct {
+		PluginsDir string `yaml:"plugins_dir" env:"PLUGINS_DIR"`
+	}
+	wasmConfig struct {
+		ModulesDir string `yaml:"modules_dir" env:"MODULES_DIR"`
+	}
+	kubernetesConfig struct {
+		Namespace  string `yaml:"namespace" env:"NAMESPACE"`
+		KubeConfig string `yaml:"kubeconfig" env:"KUBECONFIG"`
+	}
+	securityConfig struct {
+		SeccompProfilesDir string         `yaml:"seccomp_profiles_dir" env:"SECCOMP_PROFILES_DIR"`
+		Baseline           baselineConfig `yaml:"baseline" env:", prefix=BASELINE_"`
+	}
+	baselineConfig struct {
+		ReadOnly        bool   `yaml:"read_only" env:"READ_ONLY, default=true"`
+		CapDropAll      bool   `yaml:"cap_drop_all" env:"CAP_DROP_ALL, default=true"`
+		NoNewPrivileges bool   `yaml:"no_new_privileges" env:"NO_NEW_PRIVILEGES, default=true"`
+		PidsLimit       int64  `yaml:"pids_limit" env:"PIDS_LIMIT, default=128"`
+		User            string `yaml:"user" env:"USER, default=65534:65534"`
+	}
+	limitsConfig struct {
+		Networks  []string `yaml:"networks" env:"NETWORKS"`
+		MaxMemory string   `yaml:"max_memory" env:"MAX_MEMORY, default=1g"`
+		MaxCPUs   float64  `yaml:"max_cpus" env:"MAX_CPUS, default=2"`
+	}
+	imagesConfig struct {
+		Concurrency int           `yaml:"concurrency" env:"CONCURRENCY, default=2"`
+		Interval    time.Duration `yaml:"interval" env:"INTERVAL, default=10m"`
+		GC          bool          `yaml:"gc" env:"GC"`
+	}
+	catalogConfig struct {
+		// Interval of background catalog syncs, disabled when zero.
+		Interval time.Duration `yaml:"interval" env:"INTERVAL"`
+	}
+	modulesConfig struct {
+		// Dir has read-only dependency modules as <name>/<version>.binpb descriptor sets.
+		Dir string `yaml:"dir" env:"DIR"`
+	}
+	conformanceConfig struct {
+		// Dir has fixtures of plugins as <group>/<name>/<version>/<case>/request.json and response.json.
+		Dir string `yaml:"dir" env:"DIR"`
+	}
+	recorderConfig struct {
+		// File receives sampled GenerateCode calls as JSON lines, disabled when empty.
+		File       string  `yaml:"file" env:"FILE"`
+		SampleRate float64 `yaml:"sample_rate" env:"SAMPLE_RATE, default=1"`
+	}
+	determinismConfig struct {
+		// Interval of background determinism checks of every plugin, disabled when zero.
+		Interval time.Duration `yaml:"interval" env:"INTERVAL"`
+	}
+	secretsConfig struct {
+		Key string `yaml:"key" env:"KEY"`
+	}
+	groupConfig struct {
+		Runtime         string   `yaml:"runtime"`
+		SeccompProfile  string   `yaml:"seccomp_profile"`
+		AppArmorProfile string   `yaml:"apparmor_profile"`
+		CapDrop         []string `yaml:"cap_drop"`
+		NoNewPrivileges bool     `yaml:"no_new_privileges"`
+		Runtimes        []string `yaml:"runtimes"`
+		Executors       []string `yaml:"executors"`
+		PublicKeys      []string `yaml:"public_keys"`
+	}
+	upstreamConfig struct {
+		Domain   string `yaml:"domain"`
+		Username string `yaml:"username"`
+		// PasswordEnv is the name of the environment variable with the password.
+		PasswordEnv string `yaml:"password_env"`
+		Insecure    bool   `yaml:"insecure"`
+		CAFile      string `yaml:"ca_file"`
+		PullSecret  string `yaml:"pull_secret"`
 	}
 )
 
 var (
 	cfgFile  = &flags.File{DefaultPath: "", MaxSize: configFileSize}
 	logLevel = &flags.Level{Level: slog.LevelDebug}
+
+	conformance       string
+	conformanceUpdate bool
+	replayFile        string
+	replayTarget      string
+)
+
+var (
+	errConformanceFailed = errors.New("conformance failed")
+	errReplayMismatch    = errors.New("replay mismatch")
 )
 
 func main() {
 	flag.Var(cfgFile, "cfg", "path to config file")
 	flag.Var(logLevel, "log_level", "log level")
+	flag.StringVar(&conformance, "conformance", "", `run the conformance suite of the plugin, e.g. "protobuf/go:v1.36.10", or of every plugin with "all", and exit`)
+	flag.BoolVar(&conformanceUpdate, "conformance_update", false, "replace the expected responses of the conformance suite which differ")
+	flag.StringVar(&replayFile, "replay", "", "replay the GenerateCode calls recorded in the file, print the report and exit")
+	flag.StringVar(&replayTarget, "replay_target", "", "gRPC address of the service instance the calls are replayed against, the calls run in-process when empty")
 	flag.Parse()
 
 	log := buildLogger(logLevel.Level)
@@ -93,9 +209,19 @@ func start(ctx context.Context, cfgFile *flags.File, appName string) error {
 	cfg := config{}
 
 	if !cfgFile.IsNil() {
-		err := yaml.NewDecoder(cfgFile).Decode(&cfg)
+		// Defaults of omitted options, e.g. the security baseline, are the same as without the file.
+		err := envconfig.ProcessWith(ctx, &envconfig.Config{Target: &cfg, Lookuper: envconfig.MapLookuper(nil)})
 		if err != nil {
-			return fmt.Errorf("yaml.NewDecoder.Decode: %w", err)
+			return fmt.Errorf("envconfig.ProcessWith: %w", err)
+		}
+
+		// Misplaced options, e.g. group keys under the baseline, are errors instead of being dropped.
+		decoder := yaml.NewDecoder(cfgFile)
+		decoder.KnownFields(true)
+
+		err = decoder.Decode(&cfg)
+		if err != nil {
+			return fmt.Errorf("decoder.Decode: %w", err)
 		}
 	} else {
 		err := envconfig.Process(ctx, &cfg)
@@ -113,6 +239,16 @@ func run(ctx context.Context, cfg config, reg *prometheus.Registry, namespace st
 	log := logger.FromContext(ctx)
 	m := metrics.New(reg, namespace)
 
+	if replayFile != "" && replayTarget != "" {
+		conn, err := grpc.NewClient(replayTarget, grpc.WithTransportCredentials(insecure.NewCredentials()))
+		if err != nil {
+			return fmt.Errorf("grpc.NewClient: %w", err)
+		}
+		defer conn.Close() //nolint:errcheck // The connection is closed on exit.
+
+		return runReplay(ctx, replayFile, api.ClientGenerate(conn))
+	}
+
 	r, err := registry.New(ctx, reg, namespace, registry.Config{
 		Postgres: connectors.Raw{
 			Query: cfg.DB.Postgres,
@@ -120,6 +256,40 @@ func run(ctx context.Context, cfg config, reg *prometheus.Registry, namespace st
 		MigrateDir: cfg.DB.MigrateDir,
 		Driver:     cfg.DB.Driver,
 		Domain:     cfg.Registry.Domain,
+		Registries: upstreams(cfg.Registry.Registries),
+
+		ContainerEngine: cfg.Registry.Container.Engine,
+		ContainerBinary: cfg.Registry.Container.Binary,
+
+		LocalPluginsDir: cfg.Registry.Local.PluginsDir,
+		WasmModulesDir:  cfg.Registry.Wasm.ModulesDir,
+
+		KubernetesNamespace: cfg.Registry.Kubernetes.Namespace,
+		KubeConfig:          cfg.Registry.Kubernetes.KubeConfig,
+
+		SeccompProfilesDir: cfg.Registry.Security.SeccompProfilesDir,
+		Groups:             groupPolicies(cfg.Registry.Groups),
+		Baseline: registry.Baseline{
+			ReadOnly:        cfg.Registry.Security.Baseline.ReadOnly,
+			CapDropAll:      cfg.Registry.Security.Baseline.CapDropAll,
+			NoNewPrivileges: cfg.Registry.Security.Baseline.NoNewPrivileges,
+			PidsLimit:       cfg.Registry.Security.Baseline.PidsLimit,
+			User:            cfg.Registry.Security.Baseline.User,
+		},
+		Limits: registry.Limits{
+			Networks:  cfg.Registry.Limits.Networks,
+			MaxMemory: cfg.Registry.Limits.MaxMemory,
+			MaxCPUs:   cfg.Registry.Limits.MaxCPUs,
+		},
+		SecretsKey: cfg.Registry.Secrets.Key,
+		Images: registry.ImagesConfig{
+			Concurrency: cfg.Registry.Images.Concurrency,
+			Interval:    cfg.Registry.Images.Interval,
+			GC:          cfg.Registry.Images.GC,
+		},
+		CatalogInterval: cfg.Registry.Catalog.Interval,
+		ModulesDir:      cfg.Registry.Modules.Dir,
+		ConformanceDir:  cfg.Registry.Conformance.Dir,
 	})
 	if err != nil {
 		return fmt.Errorf("repo.New: %w", err)
@@ -132,9 +302,29 @@ func run(ctx context.Context, cfg config, reg *prometheus.Registry, namespace st
 		}
 	}()
 
-	module := core.New(adapter_metrics.New(reg, namespace), r)
+	module := core.New(adapter_metrics.New(reg, namespace), r, r, r, r, r)
 
-	grpcAPI := api.New(ctx, m, module, reg, namespace)
+	if conformance != "" {
+		return runConformance(ctx, module, conformance, conformanceUpdate)
+	}
+
+	if replayFile != "" {
+		return runReplay(ctx, replayFile, api.CoreGenerate(module))
+	}
+
+	recorder, err := api.NewRecorder(cfg.Recorder.File, cfg.Recorder.SampleRate)
+	if err != nil {
+		return fmt.Errorf("api.NewRecorder: %w", err)
+	}
+
+	defer func() {
+		err := recorder.Close()
+		if err != nil {
+			log.Error("close recorder", slog.String(logger.Error.String(), err.Error()))
+		}
+	}()
+
+	grpcAPI := api.New(ctx, m, module, reg, namespace, recorder, cfg.Server.AdminToken)
 
 	const healthTimeout = 1 * time.Second
 
@@ -152,6 +342,12 @@ func run(ctx context.Context, cfg config, reg *prometheus.Registry, namespace st
 				Timeout: healthTimeout,
 				Check:   r.Health,
 			},
+			health.Config{
+				Name:      "images",
+				Timeout:   healthTimeout,
+				SkipOnErr: true,
+				Check:     r.ImagesHealth,
+			},
 		),
 	)
 	if err != nil {
@@ -163,9 +359,155 @@ func run(ctx context.Context, cfg config, reg *prometheus.Registry, namespace st
 		serve.Metrics(log.With(slog.String(logger.Module.String(), "metric")), cfg.Server.Host, cfg.Server.Port.Metric, reg),
 		serve.GRPC(log.With(slog.String(logger.Module.String(), "gRPC")), cfg.Server.Host, cfg.Server.Port.GRPC, grpcAPI),
 		serve.HTTP(log.With(slog.String(logger.Module.String(), "health")), cfg.Server.Host, cfg.Server.Port.Health, h.Handler()),
+		r.RunImageManager,
+		r.RunCatalogSync,
+		func(ctx context.Context) error {
+			return module.RunDeterminismChecks(ctx, cfg.Determinism.Interval)
+		},
+		serve.GRPCGateWay(log.With(slog.String(logger.Module.String(), "gateway")), cfg.Server.Host, cfg.Server.Port.HTTP, serve.GateWayConfig{
+			GRPCServerPort: cfg.Server.Port.GRPC,
+			Reg:            reg,
+			Namespace:      namespace,
+			GRPCGWPattern:  "/v1/",
+			Register:       api.Gateway,
+			Healthcheck:    h.Handler(),
+		}),
 	)
 }
 
+// runConformance runs the conformance suite and prints the report.
+func runConformance(ctx context.Context, module *core.Core, pluginName string, update bool) error {
+	var reports []core.ConformanceReport
+	if pluginName == "all" {
+		var err error
+		reports, err = module.ConformanceAll(ctx, update)
+		if err != nil {
+			return fmt.Errorf("module.ConformanceAll: %w", err)
+		}
+	} else {
+		report, err := module.Conformance(ctx, pluginName, update)
+		if err != nil {
+			return fmt.Errorf("module.Conformance: %w", err)
+		}
+
+		reports = append(reports, *report)
+	}
+
+	failed := 0
+	for _, report := range reports {
+		status := "PASS"
+		if slices.ContainsFunc(report.Cases, func(c core.ConformanceCase) bool { return !c.Passed && !c.Updated }) {
+			status = "FAIL"
+			failed++
+		}
+
+		fmt.Printf("%s %s: %d passed, %d failed\n", status, report.Plugin, report.Passed, report.Failed)
+
+		for _, result := range report.Cases {
+			switch {
+			case result.Passed:
+				continue
+			case result.Updated:
+				fmt.Printf("  UPDATED %s\n", result.Name)
+			default:
+				fmt.Printf("  FAIL %s\n", result.Name)
+			}
+
+			if result.Error != "" {
+				fmt.Printf("    %s\n", result.Error)
+			}
+
+			for _, file := range result.Files {
+				fmt.Printf("    %s %s (+%d -%d)\n%s", file.Status, file.Name, file.LinesAdded, file.LinesRemoved, file.Diff)
+			}
+		}
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("%w: %d of %d plugins", errConformanceFailed, failed, len(reports))
+	}
+
+	return nil
+}
+
+// runReplay replays the recorded calls and prints the report.
+func runReplay(ctx context.Context, file string, generate api.GenerateFunc) error {
+	records, err := os.Open(file) //nolint:gosec // The file is a flag.
+	if err != nil {
+		return fmt.Errorf("os.Open: %w", err)
+	}
+	defer records.Close() //nolint:errcheck // The file is only read.
+
+	report, err := api.Replay(ctx, records, generate)
+	if err != nil {
+		return fmt.Errorf("api.Replay: %w", err)
+	}
+
+	for _, result := range report.Results {
+		if result.Matched {
+			continue
+		}
+
+		fmt.Printf("MISMATCH line %d %s: recorded %s, replayed %s\n", result.Line, result.Plugin, result.Recorded, result.Replayed)
+		if result.Mismatch != "" {
+			fmt.Printf("  %s\n", result.Mismatch)
+		}
+
+		for _, file := range result.Files {
+			fmt.Printf("  %s %s (+%d -%d)\n%s", file.Status, file.Name, file.LinesAdded, file.LinesRemoved, file.Diff)
+		}
+	}
+
+	fmt.Printf("%d calls: %d matched, %d mismatched\n", len(report.Results), report.Matched, report.Mismatched)
+	fmt.Printf("recorded latency: p50 %s, p95 %s, max %s\n", report.Recorded.P50, report.Recorded.P95, report.Recorded.Max)
+	fmt.Printf("replayed latency: p50 %s, p95 %s, max %s\n", report.Replayed.P50, report.Replayed.P95, report.Replayed.Max)
+
+	if report.Mismatched > 0 {
+		return fmt.Errorf("%w: %d of %d calls", errReplayMismatch, report.Mismatched, len(report.Results))
+	}
+
+	return nil
+}
+
+func groupPolicies(groups map[string]groupConfig) map[string]registry.GroupPolicy {
+	policies := make(map[string]registry.GroupPolicy, len(groups))
+	for name, group := range groups {
+		policies[name] = registry.GroupPolicy{
+			Runtime:         group.Runtime,
+			SeccompProfile:  group.SeccompProfile,
+			AppArmorProfile: group.AppArmorProfile,
+			CapDrop:         group.CapDrop,
+			NoNewPrivileges: group.NoNewPrivileges,
+			Runtimes:        group.Runtimes,
+			Executors:       group.Executors,
+			PublicKeys:      group.PublicKeys,
+		}
+	}
+
+	return policies
+}
+
+func upstreams(registries map[string]upstreamConfig) map[string]registry.Upstream {
+	upstreams := make(map[string]registry.Upstream, len(registries))
+	for name, upstream := range registries {
+		var password string
+		if upstream.PasswordEnv != "" {
+			password = os.Getenv(upstream.PasswordEnv)
+		}
+
+		upstreams[name] = registry.Upstream{
+			Domain:     upstream.Domain,
+			Username:   upstream.Username,
+			Password:   password,
+			Insecure:   upstream.Insecure,
+			CAFile:     upstream.CAFile,
+			PullSecret: upstream.PullSecret,
+		}
+	}
+
+	return upstreams
+}
+
 func buildLogger(level slog.Level) *slog.Logger {
 	return slog.New(
 		slog.NewJSONHandler(
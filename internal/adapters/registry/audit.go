@@ -0,0 +1,35 @@
+package registry
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/gofrs/uuid/v5"
+	"github.com/jmoiron/sqlx"
+)
+
+// Audit events.
+const (
+	auditSecurityOverride = "security_override"
+	auditCatalogSync      = "catalog_sync"
+	// auditEffectiveParameter is written on every run of a plugin with enforced parameters.
+	auditEffectiveParameter = "effective_parameter"
+)
+
+// audit writes an event of the plugin to the audit log.
+func audit(ctx context.Context, d sqlx.ExecerContext, pluginID uuid.UUID, event string, details any) error {
+	content, err := json.Marshal(details)
+	if err != nil {
+		return fmt.Errorf("json.Marshal: %w", err)
+	}
+
+	query := "insert into audit_log (plugin_id, event, details) values ($1, $2, $3)"
+
+	_, err = d.ExecContext(ctx, query, pluginID, event, content)
+	if err != nil {
+		return fmt.Errorf("d.ExecContext: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,140 @@
+package registry
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strings"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/sipki-tech/dev-platform/logger"
+
+	"github.com/easyp-tech/service/internal/core"
+)
+
+// Baseline options which a plugin can be exempted from by SecurityOverride.
+const (
+	RelaxReadOnly        = "read_only"
+	RelaxCapDrop         = "cap_drop"
+	RelaxNoNewPrivileges = "no_new_privileges"
+	RelaxPidsLimit       = "pids_limit"
+	RelaxUser            = "user"
+)
+
+const capAll = "ALL"
+
+type (
+	// Baseline is the service-wide security profile of plugin containers.
+	// Zero values disable the corresponding option.
+	Baseline struct {
+		ReadOnly        bool
+		CapDropAll      bool
+		NoNewPrivileges bool
+		PidsLimit       int64
+		// User is the non-root user of plugins which don't set their own one.
+		User string
+	}
+
+	// SecurityOverride exempts a plugin from the listed baseline options.
+	// An override is written to the audit log when the plugin is registered or synced.
+	SecurityOverride struct {
+		// Reason is required and explains why the plugin needs a weaker profile.
+		Reason string `json:"reason"`
+		// Relax lists the exempted options: "read_only", "cap_drop", "no_new_privileges",
+		// "pids_limit" or "user".
+		Relax []string `json:"relax"`
+	}
+)
+
+var relaxOptions = []string{RelaxReadOnly, RelaxCapDrop, RelaxNoNewPrivileges, RelaxPidsLimit, RelaxUser}
+
+// applyBaseline enforces the baseline on the docker section of the plugin config.
+// Options explicitly set weaker than the baseline are rejected unless relaxed by the override.
+func (r *Registry) applyBaseline(p *plugin) error {
+	override := p.pluginConfig.SecurityOverride
+	relaxed := func(option string) bool {
+		return override != nil && slices.Contains(override.Relax, option)
+	}
+
+	if override != nil {
+		if strings.TrimSpace(override.Reason) == "" {
+			return fmt.Errorf("%w: security_override requires a reason", core.ErrInvalidPluginConfig)
+		}
+
+		for _, option := range override.Relax {
+			if !slices.Contains(relaxOptions, option) {
+				return fmt.Errorf("%w: unknown security_override option %q", core.ErrInvalidPluginConfig, option)
+			}
+		}
+	}
+
+	dockerConfig := DockerConfig{}
+	if p.pluginConfig.Docker != nil {
+		dockerConfig = *p.pluginConfig.Docker
+	}
+
+	if r.baseline.ReadOnly && !relaxed(RelaxReadOnly) {
+		dockerConfig.ReadOnly = true
+	}
+
+	if r.baseline.CapDropAll && !relaxed(RelaxCapDrop) && !slices.Contains(dockerConfig.CapDrop, capAll) {
+		dockerConfig.CapDrop = append(slices.Clone(dockerConfig.CapDrop), capAll)
+	}
+
+	if r.baseline.NoNewPrivileges && !relaxed(RelaxNoNewPrivileges) {
+		dockerConfig.NoNewPrivileges = true
+	}
+
+	if r.baseline.PidsLimit > 0 && !relaxed(RelaxPidsLimit) {
+		switch {
+		case dockerConfig.PidsLimit == 0:
+			dockerConfig.PidsLimit = r.baseline.PidsLimit
+		case dockerConfig.PidsLimit < 0 || dockerConfig.PidsLimit > r.baseline.PidsLimit:
+			return fmt.Errorf("%w: pids_limit %d exceeds the baseline %d, use security_override",
+				core.ErrInvalidPluginConfig, dockerConfig.PidsLimit, r.baseline.PidsLimit)
+		}
+	}
+
+	if r.baseline.User != "" && !relaxed(RelaxUser) {
+		switch {
+		case dockerConfig.User == "":
+			dockerConfig.User = r.baseline.User
+		case isRootUser(dockerConfig.User):
+			return fmt.Errorf("%w: user %q is root, use security_override", core.ErrInvalidPluginConfig, dockerConfig.User)
+		}
+	}
+
+	p.pluginConfig.Docker = &dockerConfig
+
+	return nil
+}
+
+// auditOverride logs and audits the security override of the plugin, if any.
+// It is called when the config is written, not on every run.
+func auditOverride(ctx context.Context, d sqlx.ExecerContext, p *plugin) error {
+	override := p.pluginConfig.SecurityOverride
+	if override == nil || len(override.Relax) == 0 {
+		return nil
+	}
+
+	logger.FromContext(ctx).Warn("security baseline override",
+		slog.String("plugin", p.GroupName+"/"+p.Name+":"+p.Version),
+		slog.String("relax", strings.Join(override.Relax, ",")),
+		slog.String("reason", override.Reason),
+	)
+
+	err := audit(ctx, d, p.ID, auditSecurityOverride, override)
+	if err != nil {
+		return fmt.Errorf("audit: %w", err)
+	}
+
+	return nil
+}
+
+// isRootUser reports whether the user[:group] of a container is root.
+func isRootUser(user string) bool {
+	name, _, _ := strings.Cut(user, ":")
+
+	return name == "root" || name == "0"
+}
@@ -0,0 +1,218 @@
+package registry
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/sipki-tech/dev-platform/logger"
+
+	"github.com/easyp-tech/service/internal/core"
+)
+
+// Sync actions of catalog images.
+const (
+	catalogCreated = "created"
+	catalogUpdated = "updated"
+)
+
+// signatureTagPattern matches tags of cosign signatures and attestations, they are not plugin images.
+var signatureTagPattern = regexp.MustCompile(`^sha256-[a-f0-9]{64}(\.[a-z]+)?$`)
+
+// SyncCatalog implements core.Registry.
+// Every tag of a <group>/<name> repository in the default registry is upserted as a plugin version.
+// The config of a new plugin is taken from the image label, the config of a registered one
+// is replaced only when the label differs. The manifest is always refreshed. Plugins are never removed, orphaned ones are reported.
+func (r *Registry) SyncCatalog(ctx context.Context) (*core.CatalogReport, error) {
+	u := r.upstreams[""]
+
+	repositories, err := u.oci.catalog(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("u.oci.catalog: %w", err)
+	}
+
+	report := &core.CatalogReport{}
+	found := make(map[string]bool)
+
+	for _, repository := range repositories {
+		tags, err := u.oci.tags(ctx, repository)
+		if err != nil {
+			return nil, fmt.Errorf("u.oci.tags: %w", err)
+		}
+
+		group, name, _ := strings.Cut(repository, "/")
+		for _, tag := range tags {
+			if signatureTagPattern.MatchString(tag) {
+				continue
+			}
+
+			image := u.host() + "/" + repository + ":" + tag
+			if !core.ValidPluginName(group, name, tag) {
+				report.OrphanedImages = append(report.OrphanedImages, core.OrphanedImage{
+					Image:  image,
+					Reason: fmt.Sprintf("%s: expected <group>/<name>:v<version>", core.ErrInvalidPluginName),
+				})
+
+				continue
+			}
+
+			found[pluginKey(core.PluginInfo{Group: group, Name: name, Version: tag})] = true
+
+			info, action, err := r.syncImage(ctx, u, group, name, tag)
+			switch {
+			case err != nil:
+				report.OrphanedImages = append(report.OrphanedImages, core.OrphanedImage{Image: image, Reason: err.Error()})
+			case action == catalogCreated:
+				report.Created = append(report.Created, *info)
+				r.images.enqueue(*info)
+			case action == catalogUpdated:
+				report.Updated = append(report.Updated, *info)
+			}
+		}
+	}
+
+	err = r.sql.NoTx(func(d *sqlx.DB) error {
+		var rows []plugin
+		query := "select " + pluginColumns + " from plugins where image = '' and registry = '' order by group_name, name, version"
+
+		err := d.SelectContext(ctx, &rows, query)
+		if err != nil {
+			return fmt.Errorf("d.SelectContext: %w", err)
+		}
+
+		for i := range rows {
+			info := rows[i].Info(ctx)
+			if !found[pluginKey(*info)] {
+				report.OrphanedPlugins = append(report.OrphanedPlugins, *info)
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("sql.NoTx: %w", err)
+	}
+
+	return report, nil
+}
+
+// syncImage upserts the plugin of the image and returns the sync action, empty if nothing changed.
+func (r *Registry) syncImage(ctx context.Context, u *upstream, group, name, version string) (*core.PluginInfo, string, error) {
+	labels, err := u.oci.labels(ctx, group+"/"+name, version)
+	if err != nil {
+		return nil, "", fmt.Errorf("u.oci.labels: %w", err)
+	}
+
+	config, labeled := labels[labelPluginConfig]
+	if !labeled {
+		config = "{}"
+	}
+
+	manifest, err := parseManifest(labels)
+	if err != nil {
+		return nil, "", fmt.Errorf("parseManifest: %w", err)
+	}
+
+	p := plugin{GroupName: group, Name: name, Version: version, Config: json.RawMessage(config), Manifest: manifest}
+
+	err = parseConfig(p.Config, &p.pluginConfig)
+	if err != nil {
+		return nil, "", fmt.Errorf("parseConfig: %w", err)
+	}
+
+	err = r.prepare(&p)
+	if err != nil {
+		return nil, "", fmt.Errorf("r.prepare: %w", err)
+	}
+
+	err = r.reference(&p)
+	if err != nil {
+		return nil, "", fmt.Errorf("r.reference: %w", err)
+	}
+
+	var action string
+	err = r.sql.Tx(ctx, nil, func(tx *sqlx.Tx) error {
+		// Unlabeled images don't touch registered configs. xmax is zero for inserted rows.
+		query := `insert into plugins (group_name, name, version, config, manifest) values ($1, $2, $3, $4, $6)
+			on conflict (group_name, name, version) do update
+				set config = case when $5 then excluded.config else plugins.config end, manifest = excluded.manifest
+				where plugins.image = '' and plugins.registry = ''
+					and (($5 and plugins.config <> excluded.config) or plugins.manifest <> excluded.manifest)
+			returning id, created_at, xmax = 0`
+
+		var inserted bool
+		err := tx.QueryRowxContext(ctx, query, group, name, version, p.Config, labeled, p.Manifest).Scan(&p.ID, &p.CreatedAt, &inserted)
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			return nil
+		case err != nil:
+			return fmt.Errorf("tx.QueryRowxContext: %w", err)
+		}
+
+		action = catalogUpdated
+		if inserted {
+			action = catalogCreated
+		}
+
+		details := map[string]any{"action": action, "manifest": p.Manifest}
+		if labeled {
+			details["config"] = p.Config
+		}
+
+		err = audit(ctx, tx, p.ID, auditCatalogSync, details)
+		if err != nil {
+			return fmt.Errorf("audit: %w", err)
+		}
+
+		err = auditOverride(ctx, tx, &p)
+		if err != nil {
+			return fmt.Errorf("auditOverride: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, "", fmt.Errorf("sql.Tx: %w", err)
+	}
+
+	return p.Info(ctx), action, nil
+}
+
+// RunCatalogSync syncs the catalog every Config.CatalogInterval until ctx is done,
+// it does nothing when the interval is zero.
+func (r *Registry) RunCatalogSync(ctx context.Context) error {
+	if r.catalogInterval <= 0 {
+		return nil
+	}
+
+	ticker := time.NewTicker(r.catalogInterval)
+	defer ticker.Stop()
+
+	for {
+		report, err := r.SyncCatalog(ctx)
+		switch {
+		case err != nil && ctx.Err() == nil:
+			logger.FromContext(ctx).Error("sync catalog", slog.String(logger.Error.String(), err.Error()))
+		case err == nil:
+			logger.FromContext(ctx).Info("sync catalog",
+				slog.Int("created", len(report.Created)),
+				slog.Int("updated", len(report.Updated)),
+				slog.Int("orphaned_plugins", len(report.OrphanedPlugins)),
+				slog.Int("orphaned_images", len(report.OrphanedImages)),
+			)
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
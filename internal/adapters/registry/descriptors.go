@@ -0,0 +1,104 @@
+package registry
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/descriptorpb"
+
+	"github.com/easyp-tech/service/internal/core"
+)
+
+var _ core.DescriptorStore = &Registry{}
+
+// descriptor is a stored file descriptor.
+type descriptor struct {
+	Hash    string `db:"hash"`
+	Content []byte `db:"content"`
+}
+
+// MissingDescriptors implements core.DescriptorStore.
+func (r *Registry) MissingDescriptors(ctx context.Context, hashes []string) (missing []string, err error) {
+	err = r.sql.NoTx(func(d *sqlx.DB) error {
+		query := "select hash from unnest($1::text[]) as hash where hash not in (select hash from descriptors)"
+
+		err := d.SelectContext(ctx, &missing, query, pq.Array(hashes))
+		if err != nil {
+			return fmt.Errorf("d.SelectContext: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("sql.NoTx: %w", err)
+	}
+
+	return missing, nil
+}
+
+// SaveDescriptors implements core.DescriptorStore.
+func (r *Registry) SaveDescriptors(ctx context.Context, descriptors map[string]*descriptorpb.FileDescriptorProto) error {
+	rows := make([]descriptor, 0, len(descriptors))
+	for hash, file := range descriptors {
+		content, err := proto.MarshalOptions{Deterministic: true}.Marshal(file)
+		if err != nil {
+			return fmt.Errorf("proto.Marshal: %w", err)
+		}
+
+		rows = append(rows, descriptor{Hash: hash, Content: content})
+	}
+
+	if len(rows) == 0 {
+		return nil
+	}
+
+	err := r.sql.NoTx(func(d *sqlx.DB) error {
+		query := "insert into descriptors (hash, content) values (:hash, :content) on conflict (hash) do nothing"
+
+		_, err := d.NamedExecContext(ctx, query, rows)
+		if err != nil {
+			return fmt.Errorf("d.NamedExecContext: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("sql.NoTx: %w", err)
+	}
+
+	return nil
+}
+
+// Descriptors implements core.DescriptorStore.
+func (r *Registry) Descriptors(ctx context.Context, hashes []string) (map[string]*descriptorpb.FileDescriptorProto, error) {
+	var rows []descriptor
+	err := r.sql.NoTx(func(d *sqlx.DB) error {
+		query := "select hash, content from descriptors where hash = any($1)"
+
+		err := d.SelectContext(ctx, &rows, query, pq.Array(hashes))
+		if err != nil {
+			return fmt.Errorf("d.SelectContext: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("sql.NoTx: %w", err)
+	}
+
+	descriptors := make(map[string]*descriptorpb.FileDescriptorProto, len(rows))
+	for _, row := range rows {
+		file := &descriptorpb.FileDescriptorProto{}
+		err := proto.Unmarshal(row.Content, file)
+		if err != nil {
+			return nil, fmt.Errorf("proto.Unmarshal: %w", err)
+		}
+
+		descriptors[row.Hash] = file
+	}
+
+	return descriptors, nil
+}
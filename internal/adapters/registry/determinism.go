@@ -0,0 +1,62 @@
+package registry
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/gofrs/uuid/v5"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+
+	"github.com/easyp-tech/service/internal/core"
+)
+
+// determinismCheck is the last determinism check of a plugin.
+type determinismCheck struct {
+	PluginID    uuid.UUID      `db:"plugin_id"`
+	Differences pq.StringArray `db:"differences"`
+	Error       string         `db:"error"`
+	Source      string         `db:"source"`
+	CheckedAt   time.Time      `db:"checked_at"`
+}
+
+// SaveDeterminism implements core.Registry.
+func (r *Registry) SaveDeterminism(ctx context.Context, pluginID uuid.UUID, check core.DeterminismCheck) error {
+	return r.sql.NoTx(func(d *sqlx.DB) error {
+		query := `insert into determinism_checks (plugin_id, differences, error, source, checked_at)
+			values ($1, $2, $3, $4, $5)
+			on conflict (plugin_id) do update set differences = excluded.differences, error = excluded.error,
+				source = excluded.source, checked_at = excluded.checked_at`
+
+		_, err := d.ExecContext(ctx, query, pluginID, pq.StringArray(check.Differences), check.Error, check.Source, check.CheckedAt)
+		if err != nil {
+			return fmt.Errorf("d.ExecContext: %w", err)
+		}
+
+		return nil
+	})
+}
+
+// determinismChecks returns the last determinism checks by plugin.
+func determinismChecks(ctx context.Context, d *sqlx.DB) (map[uuid.UUID]*core.DeterminismCheck, error) {
+	var rows []determinismCheck
+	query := "select plugin_id, differences, error, source, checked_at from determinism_checks"
+
+	err := d.SelectContext(ctx, &rows, query)
+	if err != nil {
+		return nil, fmt.Errorf("d.SelectContext: %w", err)
+	}
+
+	checks := make(map[uuid.UUID]*core.DeterminismCheck, len(rows))
+	for _, row := range rows {
+		checks[row.PluginID] = &core.DeterminismCheck{
+			Differences: row.Differences,
+			Error:       row.Error,
+			Source:      row.Source,
+			CheckedAt:   row.CheckedAt,
+		}
+	}
+
+	return checks, nil
+}
@@ -0,0 +1,228 @@
+package registry
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/easyp-tech/service/internal/core"
+)
+
+var (
+	_ executor       = &dockerExecutor{}
+	_ puller         = &dockerExecutor{}
+	_ imageCollector = &dockerExecutor{}
+)
+
+// dockerExecutor runs plugins as Docker containers.
+type dockerExecutor struct {
+	binary     string
+	seccompDir string
+	// domain is the default registry, only its images are collected.
+	domain string
+	// env points the engine to credentials of the upstream registries.
+	env []string
+}
+
+// execute implements executor.
+func (e *dockerExecutor) execute(ctx context.Context, p *plugin, input []byte) ([]byte, error) {
+	imageName := p.image()
+
+	// Get Docker configuration
+	dockerConfig := p.pluginConfig.Docker
+	if dockerConfig == nil {
+		dockerConfig = &DockerConfig{}
+	}
+
+	security, err := securityArgs(dockerConfig, e.seccompDir)
+	if err != nil {
+		return nil, fmt.Errorf("securityArgs: %w", err)
+	}
+
+	secrets, cleanup, err := secretArgs(p)
+	if err != nil {
+		return nil, fmt.Errorf("secretArgs: %w", err)
+	}
+	defer cleanup()
+
+	args := append(dockerArgs(dockerConfig), security...)
+	args = append(args, secrets...)
+	args = append(args, imageName)
+
+	return runContainer(ctx, e.binary, args, e.env, input)
+}
+
+// pull implements puller.
+func (e *dockerExecutor) pull(ctx context.Context, p *plugin) error {
+	return pullImage(ctx, e.binary, e.env, p.image())
+}
+
+// collect implements imageCollector.
+func (e *dockerExecutor) collect(ctx context.Context, keep map[string]bool) error {
+	return collectImages(ctx, e.binary, e.domain, keep)
+}
+
+// dockerArgs builds Docker command with configuration from database.
+func dockerArgs(dockerConfig *DockerConfig) []string {
+	args := []string{"run", "--rm", "-i"}
+
+	// Apply Docker configuration from database
+	if dockerConfig.Network != "" {
+		args = append(args, "--network="+dockerConfig.Network)
+	} else {
+		// Default security: no network access
+		args = append(args, "--network=none")
+	}
+
+	if dockerConfig.Memory != "" {
+		args = append(args, "--memory="+dockerConfig.Memory)
+	} else {
+		// Default memory limit
+		args = append(args, "--memory=128m")
+	}
+
+	if dockerConfig.CPUs != "" {
+		args = append(args, "--cpus="+dockerConfig.CPUs)
+	} else {
+		// Default CPU limit
+		args = append(args, "--cpus=1.0")
+	}
+
+	if dockerConfig.PidsLimit != 0 {
+		args = append(args, "--pids-limit="+strconv.FormatInt(dockerConfig.PidsLimit, 10))
+	}
+
+	if dockerConfig.User != "" {
+		args = append(args, "--user="+dockerConfig.User)
+	}
+
+	if dockerConfig.WorkingDir != "" {
+		args = append(args, "--workdir="+dockerConfig.WorkingDir)
+	}
+
+	if dockerConfig.ReadOnly {
+		args = append(args, "--read-only")
+	}
+
+	// Add environment variables
+	for key, value := range dockerConfig.Env {
+		args = append(args, "--env", key+"="+value)
+	}
+
+	// Add tmpfs mounts
+	for path, opts := range dockerConfig.TmpFS {
+		if opts != "" {
+			args = append(args, "--tmpfs", path+":"+opts)
+		} else {
+			args = append(args, "--tmpfs", path)
+		}
+	}
+
+	return args
+}
+
+// securityArgs builds runtime and security options shared by Docker and Podman.
+func securityArgs(dockerConfig *DockerConfig, seccompDir string) ([]string, error) {
+	var args []string
+
+	if dockerConfig.Runtime != "" {
+		args = append(args, "--runtime="+dockerConfig.Runtime)
+	}
+
+	if dockerConfig.SeccompProfile != "" {
+		profile, err := seccompProfile(seccompDir, dockerConfig.SeccompProfile)
+		if err != nil {
+			return nil, fmt.Errorf("seccompProfile: %w", err)
+		}
+
+		args = append(args, "--security-opt=seccomp="+profile)
+	}
+
+	if dockerConfig.AppArmorProfile != "" {
+		args = append(args, "--security-opt=apparmor="+dockerConfig.AppArmorProfile)
+	}
+
+	for _, capability := range dockerConfig.CapDrop {
+		args = append(args, "--cap-drop="+capability)
+	}
+
+	if dockerConfig.NoNewPrivileges {
+		args = append(args, "--security-opt=no-new-privileges")
+	}
+
+	return args, nil
+}
+
+// seccompProfile returns the path of the named profile inside the profiles directory.
+func seccompProfile(dir, name string) (string, error) {
+	if dir == "" || name == "" || name != filepath.Base(name) || name == "." || name == ".." {
+		return "", fmt.Errorf("%w: seccomp profile %q", errInvalidFileName, name)
+	}
+
+	return filepath.Join(dir, name+".json"), nil
+}
+
+// secretArgs passes the secrets in an env file readable only by the service, so the values are
+// neither in the command line nor in the environment of the engine binary, where names like
+// DOCKER_HOST would reconfigure the engine. The returned function removes the file.
+func secretArgs(p *plugin) ([]string, func(), error) {
+	if len(p.secrets) == 0 {
+		return nil, func() {}, nil
+	}
+
+	var content strings.Builder
+	for name, value := range p.secrets {
+		// Env files have a variable per line, a line break would start another variable.
+		if strings.ContainsAny(value, "\r\n\x00") {
+			return nil, nil, fmt.Errorf("%w: secret of %s has a line break", core.ErrInvalidPluginConfig, name)
+		}
+
+		content.WriteString(name + "=" + value + "\n")
+	}
+
+	file, err := os.CreateTemp("", "easyp-secrets-*.env") // Created with 0600.
+	if err != nil {
+		return nil, nil, fmt.Errorf("os.CreateTemp: %w", err)
+	}
+
+	cleanup := func() { _ = os.Remove(file.Name()) }
+
+	_, err = file.WriteString(content.String())
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		cleanup()
+		return nil, nil, fmt.Errorf("file.WriteString: %w", err)
+	}
+
+	return []string{"--env-file", file.Name()}, cleanup, nil
+}
+
+// runContainer runs the container engine binary with input on stdin and returns its stdout.
+// env is added to the environment of the engine binary.
+func runContainer(ctx context.Context, binary string, args, env []string, input []byte) ([]byte, error) {
+	cmd := exec.CommandContext(ctx, binary, args...)
+	cmd.Stdin = bytes.NewReader(input)
+	if len(env) > 0 {
+		cmd.Env = append(os.Environ(), env...)
+	}
+
+	output, err := cmd.Output()
+	if err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) {
+			return nil, fmt.Errorf("plugin execution failed: %s, stderr: %s", err, string(exitErr.Stderr))
+		}
+
+		return nil, fmt.Errorf("cmd.Output: %w", err)
+	}
+
+	return output, nil
+}
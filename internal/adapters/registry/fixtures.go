@@ -0,0 +1,123 @@
+package registry
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/pluginpb"
+
+	"github.com/easyp-tech/service/internal/core"
+)
+
+var _ core.FixtureStore = &Registry{}
+
+// Files of a fixture, the request is either JSON or binary.
+const (
+	fixtureRequestJSON   = "request.json"
+	fixtureRequestBinary = "request.binpb"
+	fixtureResponse      = "response.json"
+)
+
+// Fixtures implements core.FixtureStore.
+// Every directory of <conformance dir>/<group>/<name>/<version> is a fixture.
+func (r *Registry) Fixtures(_ context.Context, group, name, version string) ([]core.Fixture, error) {
+	if r.conformanceDir == "" {
+		return nil, nil
+	}
+
+	dir := filepath.Join(r.conformanceDir, group, name, version)
+	entries, err := os.ReadDir(dir) // Sorted by name.
+	switch {
+	case errors.Is(err, fs.ErrNotExist):
+		return nil, nil
+	case err != nil:
+		return nil, fmt.Errorf("os.ReadDir: %w", err)
+	}
+
+	var fixtures []core.Fixture
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+
+		fixture, err := readFixture(filepath.Join(dir, entry.Name()))
+		if err != nil {
+			return nil, fmt.Errorf("fixture %s: %w", entry.Name(), err)
+		}
+
+		fixture.Name = entry.Name()
+		fixtures = append(fixtures, *fixture)
+	}
+
+	return fixtures, nil
+}
+
+func readFixture(dir string) (*core.Fixture, error) {
+	fixture := &core.Fixture{Request: &pluginpb.CodeGeneratorRequest{}}
+
+	data, err := os.ReadFile(filepath.Join(dir, fixtureRequestJSON))
+	if err == nil {
+		err = protojson.Unmarshal(data, fixture.Request)
+	} else if errors.Is(err, fs.ErrNotExist) {
+		data, err = os.ReadFile(filepath.Join(dir, fixtureRequestBinary))
+		if err == nil {
+			err = proto.Unmarshal(data, fixture.Request)
+		}
+	}
+	if err != nil {
+		return nil, fmt.Errorf("request: %w", err)
+	}
+
+	data, err = os.ReadFile(filepath.Join(dir, fixtureResponse))
+	switch {
+	case errors.Is(err, fs.ErrNotExist):
+		return fixture, nil
+	case err != nil:
+		return nil, fmt.Errorf("os.ReadFile: %w", err)
+	}
+
+	fixture.Response = &pluginpb.CodeGeneratorResponse{}
+	err = protojson.Unmarshal(data, fixture.Response)
+	if err != nil {
+		return nil, fmt.Errorf("protojson.Unmarshal: %w", err)
+	}
+
+	return fixture, nil
+}
+
+// UpdateFixture implements core.FixtureStore.
+// The response is written as indented JSON, stable across runs so it can be committed.
+func (r *Registry) UpdateFixture(_ context.Context, group, name, version string, fixture core.Fixture) error {
+	if r.conformanceDir == "" {
+		return fmt.Errorf("fixture %s: %w", fixture.Name, core.ErrNotFound)
+	}
+
+	data, err := protojson.Marshal(fixture.Response)
+	if err != nil {
+		return fmt.Errorf("protojson.Marshal: %w", err)
+	}
+
+	// protojson randomizes whitespaces, json.Indent drops them.
+	var buf bytes.Buffer
+	err = json.Indent(&buf, data, "", "  ")
+	if err != nil {
+		return fmt.Errorf("json.Indent: %w", err)
+	}
+
+	buf.WriteByte('\n')
+
+	err = os.WriteFile(filepath.Join(r.conformanceDir, group, name, version, fixture.Name, fixtureResponse), buf.Bytes(), 0o644) //nolint:gosec // Fixtures are committed files.
+	if err != nil {
+		return fmt.Errorf("os.WriteFile: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,338 @@
+package registry
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"os/exec"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/sipki-tech/dev-platform/logger"
+
+	"github.com/easyp-tech/service/internal/core"
+)
+
+// Image pull states of plugins.
+const (
+	ImagePending = "pending"
+	ImagePulling = "pulling"
+	ImageReady   = "ready"
+	ImageFailed  = "failed"
+	// ImageSkipped is set for executors which can't fetch plugins ahead of a run.
+	ImageSkipped = "skipped"
+)
+
+const (
+	defaultImagesInterval = 10 * time.Minute
+	imagesQueueSize       = 1024
+	imageNoneTag          = "<none>"
+)
+
+var errImagePull = errors.New("image pull failed")
+
+type (
+	// ImagesConfig configures pre-pulling of plugin images.
+	ImagesConfig struct {
+		// Concurrency is the number of parallel pulls, 1 when zero.
+		Concurrency int
+		// Interval between syncs with the plugins table, 10 minutes when zero.
+		// Failed pulls are retried and plugins inserted directly into the table are pulled on sync.
+		Interval time.Duration
+		// GC removes local images of the default registry domain which don't belong to registered plugins.
+		GC bool
+	}
+
+	// puller is implemented by executors which can fetch the plugin ahead of a run.
+	puller interface {
+		pull(ctx context.Context, p *plugin) error
+	}
+
+	// imageCollector is implemented by executors which keep images locally.
+	imageCollector interface {
+		// collect removes local images which are not in keep, keep contains image references
+		// and repositories of registered plugins.
+		collect(ctx context.Context, keep map[string]bool) error
+	}
+
+	// imageStatus is the pull state of a plugin.
+	imageStatus struct {
+		state     string
+		err       string
+		updatedAt time.Time
+	}
+
+	// imageManager tracks pull states and queues plugins for pulling.
+	imageManager struct {
+		cfg ImagesConfig
+
+		mu       sync.Mutex
+		statuses map[string]imageStatus
+		queue    chan core.PluginInfo
+	}
+)
+
+func newImageManager(cfg ImagesConfig) *imageManager {
+	if cfg.Concurrency <= 0 {
+		cfg.Concurrency = 1
+	}
+
+	if cfg.Interval <= 0 {
+		cfg.Interval = defaultImagesInterval
+	}
+
+	return &imageManager{
+		cfg:      cfg,
+		statuses: make(map[string]imageStatus),
+		queue:    make(chan core.PluginInfo, imagesQueueSize),
+	}
+}
+
+// enqueue marks the plugin as pending and queues it. A full queue is picked up by the next sync.
+func (m *imageManager) enqueue(info core.PluginInfo) {
+	m.set(info, ImagePending, nil)
+
+	select {
+	case m.queue <- info:
+	default:
+	}
+}
+
+func (m *imageManager) set(info core.PluginInfo, state string, err error) {
+	status := imageStatus{state: state, updatedAt: time.Now()}
+	if err != nil {
+		status.err = err.Error()
+	}
+
+	m.mu.Lock()
+	m.statuses[pluginKey(info)] = status
+	m.mu.Unlock()
+}
+
+func (m *imageManager) status(info core.PluginInfo) (imageStatus, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	status, ok := m.statuses[pluginKey(info)]
+
+	return status, ok
+}
+
+// retain drops states of plugins which are not registered anymore.
+func (m *imageManager) retain(plugins []core.PluginInfo) {
+	keys := make(map[string]bool, len(plugins))
+	for _, info := range plugins {
+		keys[pluginKey(info)] = true
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for key := range m.statuses {
+		if !keys[key] {
+			delete(m.statuses, key)
+		}
+	}
+}
+
+// RunImageManager pulls images of registered plugins until ctx is done:
+// all plugins on start, newly registered plugins right away and failed ones on every sync.
+func (r *Registry) RunImageManager(ctx context.Context) error {
+	var wg sync.WaitGroup
+	for range r.images.cfg.Concurrency {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case info := <-r.images.queue:
+					r.pullPlugin(ctx, info)
+				}
+			}
+		}()
+	}
+	defer wg.Wait()
+
+	ticker := time.NewTicker(r.images.cfg.Interval)
+	defer ticker.Stop()
+
+	for {
+		err := r.syncImages(ctx)
+		if err != nil && ctx.Err() == nil {
+			logger.FromContext(ctx).Error("sync images", slog.String(logger.Error.String(), err.Error()))
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// syncImages queues plugins which are not pulled yet and collects images of removed plugins.
+func (r *Registry) syncImages(ctx context.Context) error {
+	plugins, err := r.List(ctx)
+	if err != nil {
+		return fmt.Errorf("r.List: %w", err)
+	}
+
+	r.images.retain(plugins)
+
+	for _, info := range plugins {
+		status, ok := r.images.status(info)
+		if !ok || status.state == ImageFailed {
+			r.images.enqueue(info)
+		}
+	}
+
+	if !r.images.cfg.GC {
+		return nil
+	}
+
+	keep := make(map[string]bool, 2*len(plugins))
+	for _, info := range plugins {
+		keep[info.Image] = true
+		keep[imageRepository(info.Image)] = true
+	}
+
+	for _, e := range r.executors {
+		collector, ok := e.(imageCollector)
+		if !ok {
+			continue
+		}
+
+		err = collector.collect(ctx, keep)
+		if err != nil {
+			return fmt.Errorf("collector.collect: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// pullPlugin fetches the plugin with its executor and records the result.
+func (r *Registry) pullPlugin(ctx context.Context, info core.PluginInfo) {
+	r.images.set(info, ImagePulling, nil)
+
+	var p *plugin
+	err := r.sql.NoTx(func(d *sqlx.DB) error {
+		var err error
+		p, err = r.load(ctx, d, info.Group, info.Name, info.Version)
+		if err != nil {
+			return fmt.Errorf("r.load: %w", err)
+		}
+
+		return nil
+	})
+	if err == nil {
+		err = r.verifyImage(ctx, p)
+	}
+
+	if err != nil {
+		r.images.set(info, ImageFailed, err)
+		return
+	}
+
+	pull, ok := p.executor.(puller)
+	if !ok {
+		r.images.set(info, ImageSkipped, nil)
+		return
+	}
+
+	err = pull.pull(ctx, p)
+	if err != nil {
+		r.images.set(info, ImageFailed, err)
+		return
+	}
+
+	r.images.set(info, ImageReady, nil)
+}
+
+// ImagesHealth reports plugins whose images failed to pull.
+func (r *Registry) ImagesHealth(_ context.Context) error {
+	r.images.mu.Lock()
+	defer r.images.mu.Unlock()
+
+	var errs []error
+	for key, status := range r.images.statuses {
+		if status.state == ImageFailed {
+			errs = append(errs, fmt.Errorf("%w: %s: %s", errImagePull, key, status.err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// pullImage pulls the image with the container engine binary, args end with the image.
+// env is added to the environment of the engine binary.
+func pullImage(ctx context.Context, binary string, env []string, args ...string) error {
+	cmd := exec.CommandContext(ctx, binary, append([]string{"pull", "--quiet"}, args...)...)
+	if len(env) > 0 {
+		cmd.Env = append(os.Environ(), env...)
+	}
+
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("%w: %s: %s", errImagePull, err, strings.TrimSpace(string(output)))
+	}
+
+	return nil
+}
+
+// collectImages removes images of the domain which are not in keep.
+// Untagged images are removed only when their repository is not in keep.
+func collectImages(ctx context.Context, binary, domain string, keep map[string]bool) error {
+	output, err := exec.CommandContext(ctx, binary, "images",
+		"--format", "{{.Repository}}\t{{.Tag}}\t{{.ID}}",
+		"--filter", "reference="+domain+"/*/*",
+	).Output()
+	if err != nil {
+		return fmt.Errorf("%s images: %w", binary, err)
+	}
+
+	for _, line := range strings.Split(strings.TrimSpace(string(output)), "\n") {
+		repository, rest, _ := strings.Cut(line, "\t")
+		tag, id, _ := strings.Cut(rest, "\t")
+		if repository == "" || !strings.HasPrefix(repository, domain+"/") {
+			continue
+		}
+
+		reference := repository + ":" + tag
+		if tag == imageNoneTag {
+			if keep[repository] {
+				continue
+			}
+
+			reference = id
+		} else if keep[reference] {
+			continue
+		}
+
+		// Images used by running containers can't be removed, they are collected by a later sync.
+		_ = exec.CommandContext(ctx, binary, "rmi", reference).Run()
+	}
+
+	return nil
+}
+
+// imageRepository strips the tag or the digest of the image reference.
+func imageRepository(image string) string {
+	image, _, _ = strings.Cut(image, "@")
+	if i := strings.LastIndex(image, ":"); i > strings.LastIndex(image, "/") {
+		image = image[:i]
+	}
+
+	return image
+}
+
+func pluginKey(info core.PluginInfo) string {
+	return info.Group + "/" + info.Name + ":" + info.Version
+}
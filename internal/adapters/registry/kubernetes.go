@@ -0,0 +1,474 @@
+package registry
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/clientcmd"
+	"k8s.io/client-go/tools/remotecommand"
+	"k8s.io/utils/ptr"
+)
+
+var (
+	_ executor    = &kubernetesExecutor{}
+	_ podAttacher = &spdyAttacher{}
+)
+
+// Kubernetes pod settings.
+const (
+	pluginContainer     = "plugin"
+	pluginLabel         = "easyp-plugin"
+	pluginAnnotation    = "easyp.tech/plugin"
+	podPollInterval     = 250 * time.Millisecond
+	podDeleteTimeout    = 10 * time.Second
+	podActiveDeadline   = 5 * time.Minute
+	defaultPodUser      = 65534 // nobody.
+	defaultPodMemory    = "128m"
+	defaultPodCPUs      = "1.0"
+	defaultPodTmpFSSize = "64m"
+)
+
+// Container waiting reasons which never recover by themselves.
+var podFatalReasons = []string{
+	"ErrImagePull",
+	"ImagePullBackOff",
+	"InvalidImageName",
+	"CreateContainerConfigError",
+	"CreateContainerError",
+}
+
+var (
+	errPodFailed     = errors.New("plugin pod failed")
+	errPodUnschedule = errors.New("plugin pod can not be started")
+)
+
+type (
+	// podAttacher streams stdin, stdout and stderr of a running container.
+	podAttacher interface {
+		attach(ctx context.Context, namespace, pod, container string, stdin io.Reader, stdout, stderr io.Writer) error
+	}
+
+	// kubernetesExecutor runs every request in a short-lived pod.
+	// The request is streamed to the container stdin by attaching to it.
+	kubernetesExecutor struct {
+		client    kubernetes.Interface
+		attacher  podAttacher
+		namespace string
+	}
+
+	// spdyAttacher attaches to pods through the API server.
+	spdyAttacher struct {
+		client kubernetes.Interface
+		config *rest.Config
+	}
+)
+
+func newKubernetesExecutor(config *rest.Config, namespace string) (*kubernetesExecutor, error) {
+	client, err := kubernetes.NewForConfig(config)
+	if err != nil {
+		return nil, fmt.Errorf("kubernetes.NewForConfig: %w", err)
+	}
+
+	return &kubernetesExecutor{
+		client:    client,
+		attacher:  &spdyAttacher{client: client, config: config},
+		namespace: namespace,
+	}, nil
+}
+
+// kubernetesConfig loads kubeconfig from path or uses in-cluster config when path is empty.
+func kubernetesConfig(path string) (*rest.Config, error) {
+	if path == "" {
+		config, err := rest.InClusterConfig()
+		if err != nil {
+			return nil, fmt.Errorf("rest.InClusterConfig: %w", err)
+		}
+
+		return config, nil
+	}
+
+	config, err := clientcmd.BuildConfigFromFlags("", path)
+	if err != nil {
+		return nil, fmt.Errorf("clientcmd.BuildConfigFromFlags: %w", err)
+	}
+
+	return config, nil
+}
+
+// execute implements executor.
+func (e *kubernetesExecutor) execute(ctx context.Context, p *plugin, input []byte) ([]byte, error) {
+	secretName, err := e.createSecret(ctx, p)
+	if err != nil {
+		return nil, fmt.Errorf("e.createSecret: %w", err)
+	}
+
+	if secretName != "" {
+		defer func() {
+			ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), podDeleteTimeout)
+			defer cancel()
+
+			_ = e.client.CoreV1().Secrets(e.namespace).Delete(ctx, secretName, metav1.DeleteOptions{})
+		}()
+	}
+
+	pod, err := e.buildPod(p, secretName)
+	if err != nil {
+		return nil, fmt.Errorf("e.buildPod: %w", err)
+	}
+
+	pod, err = e.client.CoreV1().Pods(e.namespace).Create(ctx, pod, metav1.CreateOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("client.Pods.Create: %w", err)
+	}
+
+	defer func() {
+		ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), podDeleteTimeout)
+		defer cancel()
+
+		_ = e.client.CoreV1().Pods(e.namespace).Delete(ctx, pod.Name, metav1.DeleteOptions{
+			GracePeriodSeconds: ptr.To[int64](0),
+		})
+	}()
+
+	err = e.waitPod(ctx, pod.Name, func(pod *corev1.Pod) bool {
+		return pod.Status.Phase != corev1.PodPending
+	})
+	if err != nil {
+		return nil, fmt.Errorf("e.waitPod: %w", err)
+	}
+
+	var stdout, stderr bytes.Buffer
+	err = e.attacher.attach(ctx, e.namespace, pod.Name, pluginContainer, bytes.NewReader(input), &stdout, &stderr)
+	if err != nil {
+		return nil, fmt.Errorf("plugin execution failed: %s, stderr: %s", err, stderr.String())
+	}
+
+	var exitCode int32
+	err = e.waitPod(ctx, pod.Name, func(pod *corev1.Pod) bool {
+		for _, status := range pod.Status.ContainerStatuses {
+			if status.Name == pluginContainer && status.State.Terminated != nil {
+				exitCode = status.State.Terminated.ExitCode
+				return true
+			}
+		}
+
+		return false
+	})
+	if err != nil {
+		return nil, fmt.Errorf("e.waitPod: %w", err)
+	}
+
+	if exitCode != 0 {
+		return nil, fmt.Errorf("%w: exit code %d, stderr: %s", errPodFailed, exitCode, stderr.String())
+	}
+
+	return stdout.Bytes(), nil
+}
+
+// createSecret stores the secrets of the plugin in a Kubernetes Secret for a single pod,
+// so the values are not part of the pod spec. Returns empty name if the plugin has no secrets.
+func (e *kubernetesExecutor) createSecret(ctx context.Context, p *plugin) (string, error) {
+	if len(p.secrets) == 0 {
+		return "", nil
+	}
+
+	data := make(map[string][]byte, len(p.secrets))
+	for name, value := range p.secrets {
+		data[name] = []byte(value)
+	}
+
+	secret, err := e.client.CoreV1().Secrets(e.namespace).Create(ctx, &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			GenerateName: pluginLabel + "-",
+			Labels: map[string]string{
+				"app.kubernetes.io/name":       pluginLabel,
+				"app.kubernetes.io/managed-by": "easyp-service",
+			},
+		},
+		Immutable: ptr.To(true),
+		Data:      data,
+		Type:      corev1.SecretTypeOpaque,
+	}, metav1.CreateOptions{})
+	if err != nil {
+		return "", fmt.Errorf("client.Secrets.Create: %w", err)
+	}
+
+	return secret.Name, nil
+}
+
+// waitPod polls the pod until done returns true or the pod can not make progress.
+func (e *kubernetesExecutor) waitPod(ctx context.Context, name string, done func(*corev1.Pod) bool) error {
+	return wait.PollUntilContextCancel(ctx, podPollInterval, true, func(ctx context.Context) (bool, error) {
+		pod, err := e.client.CoreV1().Pods(e.namespace).Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			return false, fmt.Errorf("client.Pods.Get: %w", err)
+		}
+
+		if done(pod) {
+			return true, nil
+		}
+
+		for _, status := range pod.Status.ContainerStatuses {
+			waiting := status.State.Waiting
+			if waiting != nil && slices.Contains(podFatalReasons, waiting.Reason) {
+				return false, fmt.Errorf("%w: %s: %s", errPodUnschedule, waiting.Reason, waiting.Message)
+			}
+		}
+
+		if pod.Status.Phase == corev1.PodFailed {
+			return false, fmt.Errorf("%w: %s", errPodFailed, pod.Status.Reason)
+		}
+
+		return false, nil
+	})
+}
+
+// buildPod maps DockerConfig to a pod with a restrictive security context.
+// Runtime is used as the RuntimeClass name, seccomp profiles are expected in the kubelet
+// seccomp directory as <name>.json.
+// Network isolation has no pod level equivalent and has to be provided by a NetworkPolicy
+// selecting pods labeled app.kubernetes.io/name=easyp-plugin.
+// Secrets are referenced from secretName created by createSecret.
+func (e *kubernetesExecutor) buildPod(p *plugin, secretName string) (*corev1.Pod, error) {
+	dockerConfig := p.pluginConfig.Docker
+	if dockerConfig == nil {
+		dockerConfig = &DockerConfig{}
+	}
+
+	resources, err := podResources(dockerConfig)
+	if err != nil {
+		return nil, fmt.Errorf("podResources: %w", err)
+	}
+
+	user, group, err := podUser(dockerConfig.User)
+	if err != nil {
+		return nil, fmt.Errorf("podUser: %w", err)
+	}
+
+	seccomp := &corev1.SeccompProfile{
+		Type: corev1.SeccompProfileTypeRuntimeDefault,
+	}
+	if dockerConfig.SeccompProfile != "" {
+		seccomp = &corev1.SeccompProfile{
+			Type:             corev1.SeccompProfileTypeLocalhost,
+			LocalhostProfile: ptr.To(dockerConfig.SeccompProfile + ".json"),
+		}
+	}
+
+	var appArmor *corev1.AppArmorProfile
+	if dockerConfig.AppArmorProfile != "" {
+		appArmor = &corev1.AppArmorProfile{
+			Type:             corev1.AppArmorProfileTypeLocalhost,
+			LocalhostProfile: ptr.To(dockerConfig.AppArmorProfile),
+		}
+	}
+
+	// All capabilities are always dropped, CapDrop and NoNewPrivileges can't make it stricter.
+	container := corev1.Container{
+		Name:       pluginContainer,
+		Image:      p.image(),
+		WorkingDir: dockerConfig.WorkingDir,
+		Stdin:      true,
+		StdinOnce:  true,
+		Resources:  resources,
+		SecurityContext: &corev1.SecurityContext{
+			RunAsNonRoot:             ptr.To(true),
+			RunAsUser:                ptr.To(user),
+			RunAsGroup:               ptr.To(group),
+			AllowPrivilegeEscalation: ptr.To(false),
+			ReadOnlyRootFilesystem:   ptr.To(true),
+			Privileged:               ptr.To(false),
+			Capabilities: &corev1.Capabilities{
+				Drop: []corev1.Capability{"ALL"},
+			},
+			SeccompProfile:  seccomp,
+			AppArmorProfile: appArmor,
+		},
+	}
+
+	for key, value := range dockerConfig.Env {
+		container.Env = append(container.Env, corev1.EnvVar{Name: key, Value: value})
+	}
+
+	for key := range p.secrets {
+		container.Env = append(container.Env, corev1.EnvVar{
+			Name: key,
+			ValueFrom: &corev1.EnvVarSource{
+				SecretKeyRef: &corev1.SecretKeySelector{
+					LocalObjectReference: corev1.LocalObjectReference{Name: secretName},
+					Key:                  key,
+				},
+			},
+		})
+	}
+
+	var volumes []corev1.Volume
+	for path, opts := range dockerConfig.TmpFS {
+		size, err := tmpfsSize(opts)
+		if err != nil {
+			return nil, fmt.Errorf("tmpfsSize: %w", err)
+		}
+
+		name := "tmpfs-" + strconv.Itoa(len(volumes))
+		volumes = append(volumes, corev1.Volume{
+			Name: name,
+			VolumeSource: corev1.VolumeSource{
+				EmptyDir: &corev1.EmptyDirVolumeSource{
+					Medium:    corev1.StorageMediumMemory,
+					SizeLimit: resource.NewQuantity(int64(size), resource.BinarySI), //nolint:gosec // Limited by parseBytes.
+				},
+			},
+		})
+		container.VolumeMounts = append(container.VolumeMounts, corev1.VolumeMount{Name: name, MountPath: path})
+	}
+
+	var runtimeClass *string
+	if dockerConfig.Runtime != "" {
+		runtimeClass = ptr.To(dockerConfig.Runtime)
+	}
+
+	var pullSecrets []corev1.LocalObjectReference
+	if p.ref.upstream != nil && p.ref.upstream.PullSecret != "" {
+		pullSecrets = []corev1.LocalObjectReference{{Name: p.ref.upstream.PullSecret}}
+	}
+
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			GenerateName: pluginLabel + "-",
+			Labels: map[string]string{
+				"app.kubernetes.io/name":       pluginLabel,
+				"app.kubernetes.io/managed-by": "easyp-service",
+			},
+			Annotations: map[string]string{
+				pluginAnnotation: p.GroupName + "/" + p.Name + ":" + p.Version,
+			},
+		},
+		Spec: corev1.PodSpec{
+			Containers:                   []corev1.Container{container},
+			RuntimeClassName:             runtimeClass,
+			ImagePullSecrets:             pullSecrets,
+			Volumes:                      volumes,
+			RestartPolicy:                corev1.RestartPolicyNever,
+			ActiveDeadlineSeconds:        ptr.To(int64(podActiveDeadline / time.Second)),
+			AutomountServiceAccountToken: ptr.To(false),
+			EnableServiceLinks:           ptr.To(false),
+			SecurityContext: &corev1.PodSecurityContext{
+				RunAsNonRoot: ptr.To(true),
+				SeccompProfile: &corev1.SeccompProfile{
+					Type: corev1.SeccompProfileTypeRuntimeDefault,
+				},
+			},
+		},
+	}, nil
+}
+
+// attach implements podAttacher.
+func (a *spdyAttacher) attach(ctx context.Context, namespace, pod, container string, stdin io.Reader, stdout, stderr io.Writer) error {
+	req := a.client.CoreV1().RESTClient().Post().
+		Resource("pods").
+		Namespace(namespace).
+		Name(pod).
+		SubResource("attach").
+		VersionedParams(&corev1.PodAttachOptions{
+			Container: container,
+			Stdin:     true,
+			Stdout:    true,
+			Stderr:    true,
+		}, scheme.ParameterCodec)
+
+	exec, err := remotecommand.NewSPDYExecutor(a.config, "POST", req.URL())
+	if err != nil {
+		return fmt.Errorf("remotecommand.NewSPDYExecutor: %w", err)
+	}
+
+	err = exec.StreamWithContext(ctx, remotecommand.StreamOptions{
+		Stdin:  stdin,
+		Stdout: stdout,
+		Stderr: stderr,
+	})
+	if err != nil {
+		return fmt.Errorf("exec.StreamWithContext: %w", err)
+	}
+
+	return nil
+}
+
+func podResources(cfg *DockerConfig) (corev1.ResourceRequirements, error) {
+	memory := cfg.Memory
+	if memory == "" {
+		memory = defaultPodMemory
+	}
+
+	memoryBytes, err := parseBytes(memory)
+	if err != nil {
+		return corev1.ResourceRequirements{}, fmt.Errorf("parseBytes: %w", err)
+	}
+
+	cpus := cfg.CPUs
+	if cpus == "" {
+		cpus = defaultPodCPUs
+	}
+
+	cpu, err := resource.ParseQuantity(cpus)
+	if err != nil {
+		return corev1.ResourceRequirements{}, fmt.Errorf("resource.ParseQuantity: %w", err)
+	}
+
+	limits := corev1.ResourceList{
+		corev1.ResourceMemory: *resource.NewQuantity(int64(memoryBytes), resource.BinarySI), //nolint:gosec // Limited by parseBytes.
+		corev1.ResourceCPU:    cpu,
+	}
+
+	return corev1.ResourceRequirements{
+		Limits:   limits,
+		Requests: limits,
+	}, nil
+}
+
+// podUser returns numeric uid and gid, names can not be verified by runAsNonRoot.
+func podUser(user string) (int64, int64, error) {
+	if user == "" || user == "nobody" {
+		return defaultPodUser, defaultPodUser, nil
+	}
+
+	uidStr, gidStr, hasGroup := strings.Cut(user, ":")
+	uid, err := strconv.ParseInt(uidStr, 10, 64)
+	if err != nil {
+		return 0, 0, fmt.Errorf("strconv.ParseInt: %w", err)
+	}
+
+	gid := uid
+	if hasGroup {
+		gid, err = strconv.ParseInt(gidStr, 10, 64)
+		if err != nil {
+			return 0, 0, fmt.Errorf("strconv.ParseInt: %w", err)
+		}
+	}
+
+	return uid, gid, nil
+}
+
+// tmpfsSize extracts the size option from tmpfs mount options.
+func tmpfsSize(opts string) (uint64, error) {
+	for _, opt := range strings.Split(opts, ",") {
+		if size, ok := strings.CutPrefix(opt, "size="); ok {
+			return parseBytes(size)
+		}
+	}
+
+	return parseBytes(defaultPodTmpFSSize)
+}
@@ -0,0 +1,308 @@
+package registry
+
+import (
+	"context"
+	"errors"
+	"io"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+const testNamespace = "plugins"
+
+// fakeAttacher plays the plugin container: it writes the output and terminates the container.
+type fakeAttacher struct {
+	client   *fake.Clientset
+	stdout   string
+	stderr   string
+	exitCode int32
+	err      error
+	// block waits for the context instead of running the plugin.
+	block bool
+
+	input []byte
+}
+
+func (a *fakeAttacher) attach(ctx context.Context, namespace, pod, _ string, stdin io.Reader, stdout, stderr io.Writer) error {
+	if a.block {
+		<-ctx.Done()
+		return ctx.Err()
+	}
+
+	var err error
+	a.input, err = io.ReadAll(stdin)
+	if err != nil {
+		return err
+	}
+
+	_, _ = io.WriteString(stdout, a.stdout)
+	_, _ = io.WriteString(stderr, a.stderr)
+	if a.err != nil {
+		return a.err
+	}
+
+	return setContainerState(ctx, a.client, namespace, pod, corev1.ContainerState{
+		Terminated: &corev1.ContainerStateTerminated{ExitCode: a.exitCode},
+	})
+}
+
+func setContainerState(ctx context.Context, client *fake.Clientset, namespace, name string, state corev1.ContainerState) error {
+	pod, err := client.CoreV1().Pods(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return err
+	}
+
+	pod.Status.Phase = corev1.PodRunning
+	pod.Status.ContainerStatuses = []corev1.ContainerStatus{{Name: pluginContainer, State: state}}
+	_, err = client.CoreV1().Pods(namespace).UpdateStatus(ctx, pod, metav1.UpdateOptions{})
+
+	return err
+}
+
+// newFakeClient returns a client which generates names and creates pods in the phase.
+func newFakeClient(t *testing.T, phase corev1.PodPhase) *fake.Clientset {
+	t.Helper()
+
+	client := fake.NewClientset()
+
+	var names atomic.Int32
+	client.PrependReactor("create", "*", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		object := action.(k8stesting.CreateAction).GetObject()
+		if pod, ok := object.(*corev1.Pod); ok {
+			pod.Status.Phase = phase
+		}
+
+		meta, err := apimeta.Accessor(object)
+		if err != nil {
+			return true, nil, err
+		}
+
+		if meta.GetName() == "" {
+			meta.SetName(meta.GetGenerateName() + strconv.Itoa(int(names.Add(1))))
+		}
+
+		return false, nil, nil
+	})
+
+	return client
+}
+
+func newTestPlugin(cfg *DockerConfig, secrets map[string]string) *plugin {
+	return &plugin{
+		GroupName:    "protobuf",
+		Name:         "go",
+		Version:      "v1.36.10",
+		Image:        "ghcr.io/easyp-tech/protobuf/go:v1.36.10",
+		pluginConfig: PluginConfig{Executor: ExecutorKubernetes, Docker: cfg},
+		secrets:      secrets,
+	}
+}
+
+func requireNoPods(t *testing.T, client *fake.Clientset) {
+	t.Helper()
+
+	pods, err := client.CoreV1().Pods(testNamespace).List(context.Background(), metav1.ListOptions{})
+	require.NoError(t, err)
+	require.Empty(t, pods.Items)
+
+	secrets, err := client.CoreV1().Secrets(testNamespace).List(context.Background(), metav1.ListOptions{})
+	require.NoError(t, err)
+	require.Empty(t, secrets.Items)
+}
+
+func TestKubernetesBuildPod(t *testing.T) {
+	t.Parallel()
+
+	e := &kubernetesExecutor{namespace: testNamespace}
+
+	t.Run("defaults", func(t *testing.T) {
+		t.Parallel()
+
+		pod, err := e.buildPod(newTestPlugin(nil, nil), "")
+		require.NoError(t, err)
+
+		require.Len(t, pod.Spec.Containers, 1)
+		container := pod.Spec.Containers[0]
+		require.Equal(t, "ghcr.io/easyp-tech/protobuf/go:v1.36.10", container.Image)
+		require.True(t, container.Stdin)
+		require.True(t, container.StdinOnce)
+
+		require.Equal(t, "128Mi", container.Resources.Limits.Memory().String())
+		require.Equal(t, "1", container.Resources.Limits.Cpu().String())
+		require.Equal(t, container.Resources.Limits, container.Resources.Requests)
+
+		security := container.SecurityContext
+		require.True(t, *security.RunAsNonRoot)
+		require.Equal(t, int64(defaultPodUser), *security.RunAsUser)
+		require.Equal(t, int64(defaultPodUser), *security.RunAsGroup)
+		require.False(t, *security.AllowPrivilegeEscalation)
+		require.False(t, *security.Privileged)
+		require.True(t, *security.ReadOnlyRootFilesystem)
+		require.Equal(t, []corev1.Capability{"ALL"}, security.Capabilities.Drop)
+		require.Equal(t, corev1.SeccompProfileTypeRuntimeDefault, security.SeccompProfile.Type)
+		require.Nil(t, security.AppArmorProfile)
+
+		require.Nil(t, pod.Spec.RuntimeClassName)
+		require.Equal(t, corev1.RestartPolicyNever, pod.Spec.RestartPolicy)
+		require.False(t, *pod.Spec.AutomountServiceAccountToken)
+		require.False(t, *pod.Spec.EnableServiceLinks)
+		require.True(t, *pod.Spec.SecurityContext.RunAsNonRoot)
+		require.Equal(t, "protobuf/go:v1.36.10", pod.Annotations[pluginAnnotation])
+		require.Equal(t, pluginLabel, pod.Labels["app.kubernetes.io/name"])
+	})
+
+	t.Run("config", func(t *testing.T) {
+		t.Parallel()
+
+		p := newTestPlugin(&DockerConfig{
+			Memory:          "256m",
+			CPUs:            "0.5",
+			User:            "1000:2000",
+			Env:             map[string]string{"GOGC": "off"},
+			WorkingDir:      "/work",
+			TmpFS:           map[string]string{"/tmp": "rw,size=32m"},
+			Runtime:         "gvisor",
+			SeccompProfile:  "plugins",
+			AppArmorProfile: "easyp-plugin",
+		}, map[string]string{"TOKEN": "secret"})
+
+		pod, err := e.buildPod(p, "easyp-plugin-1")
+		require.NoError(t, err)
+
+		container := pod.Spec.Containers[0]
+		require.Equal(t, "256Mi", container.Resources.Limits.Memory().String())
+		require.Equal(t, "500m", container.Resources.Limits.Cpu().String())
+		require.Equal(t, "/work", container.WorkingDir)
+
+		security := container.SecurityContext
+		require.Equal(t, int64(1000), *security.RunAsUser)
+		require.Equal(t, int64(2000), *security.RunAsGroup)
+		require.Equal(t, corev1.SeccompProfileTypeLocalhost, security.SeccompProfile.Type)
+		require.Equal(t, "plugins.json", *security.SeccompProfile.LocalhostProfile)
+		require.Equal(t, "easyp-plugin", *security.AppArmorProfile.LocalhostProfile)
+
+		require.Equal(t, "gvisor", *pod.Spec.RuntimeClassName)
+
+		require.Len(t, pod.Spec.Volumes, 1)
+		require.Equal(t, corev1.StorageMediumMemory, pod.Spec.Volumes[0].EmptyDir.Medium)
+		require.Equal(t, "32Mi", pod.Spec.Volumes[0].EmptyDir.SizeLimit.String())
+		require.Equal(t, []corev1.VolumeMount{{Name: pod.Spec.Volumes[0].Name, MountPath: "/tmp"}}, container.VolumeMounts)
+
+		require.ElementsMatch(t, []corev1.EnvVar{
+			{Name: "GOGC", Value: "off"},
+			{Name: "TOKEN", ValueFrom: &corev1.EnvVarSource{SecretKeyRef: &corev1.SecretKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{Name: "easyp-plugin-1"},
+				Key:                  "TOKEN",
+			}}},
+		}, container.Env)
+	})
+
+	t.Run("root user", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := e.buildPod(newTestPlugin(&DockerConfig{User: "root"}, nil), "")
+		require.Error(t, err)
+	})
+}
+
+func TestKubernetesExecute(t *testing.T) {
+	t.Parallel()
+
+	t.Run("output", func(t *testing.T) {
+		t.Parallel()
+
+		client := newFakeClient(t, corev1.PodRunning)
+		attacher := &fakeAttacher{client: client, stdout: "response"}
+		e := &kubernetesExecutor{client: client, attacher: attacher, namespace: testNamespace}
+
+		output, err := e.execute(context.Background(), newTestPlugin(nil, map[string]string{"TOKEN": "secret"}), []byte("request"))
+		require.NoError(t, err)
+		require.Equal(t, "response", string(output))
+		require.Equal(t, "request", string(attacher.input))
+		requireNoPods(t, client)
+	})
+
+	t.Run("exit code", func(t *testing.T) {
+		t.Parallel()
+
+		client := newFakeClient(t, corev1.PodRunning)
+		attacher := &fakeAttacher{client: client, stderr: "panic", exitCode: 2}
+		e := &kubernetesExecutor{client: client, attacher: attacher, namespace: testNamespace}
+
+		_, err := e.execute(context.Background(), newTestPlugin(nil, nil), nil)
+		require.ErrorIs(t, err, errPodFailed)
+		require.ErrorContains(t, err, "panic")
+		requireNoPods(t, client)
+	})
+
+	t.Run("attach error", func(t *testing.T) {
+		t.Parallel()
+
+		client := newFakeClient(t, corev1.PodRunning)
+		attacher := &fakeAttacher{client: client, stderr: "stream closed", err: errors.New("attach failed")}
+		e := &kubernetesExecutor{client: client, attacher: attacher, namespace: testNamespace}
+
+		_, err := e.execute(context.Background(), newTestPlugin(nil, map[string]string{"TOKEN": "secret"}), nil)
+		require.ErrorContains(t, err, "attach failed")
+		require.ErrorContains(t, err, "stream closed")
+		requireNoPods(t, client)
+	})
+
+	t.Run("image pull error", func(t *testing.T) {
+		t.Parallel()
+
+		client := newFakeClient(t, corev1.PodPending)
+		client.PrependReactor("create", "pods", func(action k8stesting.Action) (bool, runtime.Object, error) {
+			pod := action.(k8stesting.CreateAction).GetObject().(*corev1.Pod)
+			pod.Status.ContainerStatuses = []corev1.ContainerStatus{{
+				Name:  pluginContainer,
+				State: corev1.ContainerState{Waiting: &corev1.ContainerStateWaiting{Reason: "ImagePullBackOff"}},
+			}}
+
+			return false, nil, nil
+		})
+		e := &kubernetesExecutor{client: client, attacher: &fakeAttacher{client: client}, namespace: testNamespace}
+
+		_, err := e.execute(context.Background(), newTestPlugin(nil, nil), nil)
+		require.ErrorIs(t, err, errPodUnschedule)
+		requireNoPods(t, client)
+	})
+
+	t.Run("timeout", func(t *testing.T) {
+		t.Parallel()
+
+		client := newFakeClient(t, corev1.PodPending)
+		e := &kubernetesExecutor{client: client, attacher: &fakeAttacher{client: client}, namespace: testNamespace}
+
+		ctx, cancel := context.WithTimeout(context.Background(), 3*podPollInterval)
+		defer cancel()
+
+		_, err := e.execute(ctx, newTestPlugin(nil, map[string]string{"TOKEN": "secret"}), nil)
+		require.ErrorIs(t, err, context.DeadlineExceeded)
+		requireNoPods(t, client)
+	})
+
+	t.Run("timeout while running", func(t *testing.T) {
+		t.Parallel()
+
+		client := newFakeClient(t, corev1.PodRunning)
+		e := &kubernetesExecutor{client: client, attacher: &fakeAttacher{client: client, block: true}, namespace: testNamespace}
+
+		ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+		defer cancel()
+
+		_, err := e.execute(ctx, newTestPlugin(nil, nil), nil)
+		require.ErrorContains(t, err, context.DeadlineExceeded.Error())
+		requireNoPods(t, client)
+	})
+}
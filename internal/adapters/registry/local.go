@@ -0,0 +1,167 @@
+package registry
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var _ executor = &localExecutor{}
+
+// Default limits of the local executor.
+const (
+	defaultLocalMemory    = "512m"
+	defaultLocalCPUTime   = 30 * time.Second
+	defaultLocalFileSize  = "64m"
+	defaultLocalOpenFiles = 64
+)
+
+var (
+	errInvalidFileName = errors.New("invalid file name")
+	errInvalidSize     = errors.New("invalid size")
+)
+
+// localExecutor runs trusted plugin binaries from a directory on the host.
+// There is no container isolation, the process is only confined by rlimits,
+// a scrubbed environment and a temporary working directory.
+type localExecutor struct {
+	dir string
+}
+
+// localLimits are the rlimits applied to the plugin process.
+type localLimits struct {
+	memory    uint64 // RLIMIT_DATA, bytes.
+	cpuTime   uint64 // RLIMIT_CPU, seconds.
+	fileSize  uint64 // RLIMIT_FSIZE, bytes.
+	openFiles uint64 // RLIMIT_NOFILE.
+}
+
+// execute implements executor.
+func (e *localExecutor) execute(ctx context.Context, p *plugin, input []byte) ([]byte, error) {
+	localConfig := p.pluginConfig.Local
+	if localConfig == nil {
+		localConfig = &LocalConfig{}
+	}
+
+	binary, err := pluginFile(e.dir, p, localConfig.Binary)
+	if err != nil {
+		return nil, fmt.Errorf("pluginFile: %w", err)
+	}
+
+	limits, err := buildLocalLimits(localConfig)
+	if err != nil {
+		return nil, fmt.Errorf("buildLocalLimits: %w", err)
+	}
+
+	workDir, err := os.MkdirTemp("", "easyp-plugin-")
+	if err != nil {
+		return nil, fmt.Errorf("os.MkdirTemp: %w", err)
+	}
+	defer os.RemoveAll(workDir) //nolint:errcheck // Best effort cleanup.
+
+	// The environment of the service is never inherited.
+	env := []string{
+		"PATH=/usr/bin:/bin",
+		"HOME=" + workDir,
+		"TMPDIR=" + workDir,
+	}
+	for key, value := range localConfig.Env {
+		env = append(env, key+"="+value)
+	}
+	env = append(env, p.secretEnv()...)
+
+	var stdout, stderr bytes.Buffer
+	err = runLocal(ctx, binary, workDir, env, limits, input, &stdout, &stderr)
+	if err != nil {
+		return nil, fmt.Errorf("plugin execution failed: %s, stderr: %s", err, stderr.String())
+	}
+
+	return stdout.Bytes(), nil
+}
+
+// pluginFile returns the path of the plugin file inside <dir>/<group>/<name>/<version>/,
+// it never leaves the plugin directory.
+func pluginFile(dir string, p *plugin, name string) (string, error) {
+	for _, part := range []string{p.GroupName, p.Name, p.Version, name} {
+		if part == "" || part != filepath.Base(part) || part == "." || part == ".." {
+			return "", fmt.Errorf("%w: %q", errInvalidFileName, part)
+		}
+	}
+
+	return filepath.Join(dir, p.GroupName, p.Name, p.Version, name), nil
+}
+
+func buildLocalLimits(cfg *LocalConfig) (localLimits, error) {
+	memory := cfg.Memory
+	if memory == "" {
+		memory = defaultLocalMemory
+	}
+
+	memoryBytes, err := parseBytes(memory)
+	if err != nil {
+		return localLimits{}, fmt.Errorf("parseBytes: %w", err)
+	}
+
+	cpuTime := defaultLocalCPUTime
+	if cfg.CPUTime != "" {
+		cpuTime, err = time.ParseDuration(cfg.CPUTime)
+		if err != nil {
+			return localLimits{}, fmt.Errorf("time.ParseDuration: %w", err)
+		}
+	}
+
+	fileSize := cfg.FileSize
+	if fileSize == "" {
+		fileSize = defaultLocalFileSize
+	}
+
+	fileSizeBytes, err := parseBytes(fileSize)
+	if err != nil {
+		return localLimits{}, fmt.Errorf("parseBytes: %w", err)
+	}
+
+	openFiles := cfg.OpenFiles
+	if openFiles == 0 {
+		openFiles = defaultLocalOpenFiles
+	}
+
+	return localLimits{
+		memory:    memoryBytes,
+		cpuTime:   uint64(max(cpuTime.Round(time.Second), time.Second) / time.Second),
+		fileSize:  fileSizeBytes,
+		openFiles: openFiles,
+	}, nil
+}
+
+// parseBytes parses sizes in the Docker format: a number with an optional
+// b, k, m or g unit suffix (e.g. "128m").
+func parseBytes(s string) (uint64, error) {
+	units := map[byte]uint64{
+		'b': 1,
+		'k': 1 << 10,
+		'm': 1 << 20,
+		'g': 1 << 30,
+	}
+
+	value := strings.ToLower(strings.TrimSpace(s))
+	multiplier := uint64(1)
+	if len(value) > 0 {
+		if unit, ok := units[value[len(value)-1]]; ok {
+			multiplier = unit
+			value = value[:len(value)-1]
+		}
+	}
+
+	n, err := strconv.ParseUint(value, 10, 64)
+	if err != nil || n == 0 {
+		return 0, fmt.Errorf("%w: %q", errInvalidSize, s)
+	}
+
+	return n * multiplier, nil
+}
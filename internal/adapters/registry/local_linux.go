@@ -0,0 +1,129 @@
+package registry
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os/exec"
+	"runtime"
+	"syscall"
+
+	"golang.org/x/sys/unix"
+)
+
+var errLocalStart = errors.New("local plugin didn't start")
+
+// runLocal starts the binary in its own process group, the limits are applied
+// while the process is stopped at exec, before the plugin runs.
+func runLocal(ctx context.Context, binary, workDir string, env []string, limits localLimits, input []byte, stdout, stderr io.Writer) error {
+	cmd := exec.CommandContext(ctx, binary)
+	cmd.Dir = workDir
+	cmd.Env = env
+	cmd.Stdout = stdout
+	cmd.Stderr = stderr
+	cmd.SysProcAttr = &syscall.SysProcAttr{
+		Setpgid:   true,
+		Pdeathsig: syscall.SIGKILL,
+		// The traced process stops with SIGTRAP right after execve.
+		Ptrace: true,
+	}
+	// Kill the whole process group, the plugin may have spawned children.
+	cmd.Cancel = func() error {
+		return syscall.Kill(-cmd.Process.Pid, syscall.SIGKILL)
+	}
+
+	stdin, err := cmd.StdinPipe()
+	if err != nil {
+		return fmt.Errorf("cmd.StdinPipe: %w", err)
+	}
+
+	err = startLimited(cmd, limits)
+	if err != nil {
+		return fmt.Errorf("startLimited: %w", err)
+	}
+
+	_, err = io.Copy(stdin, bytes.NewReader(input))
+	closeErr := stdin.Close()
+	if err == nil {
+		err = closeErr
+	}
+
+	waitErr := cmd.Wait()
+	if waitErr != nil {
+		return fmt.Errorf("cmd.Wait: %w", waitErr)
+	}
+
+	if err != nil {
+		return fmt.Errorf("write stdin: %w", err)
+	}
+
+	return nil
+}
+
+// startLimited starts the traced command, applies the limits while it is stopped at exec and detaches.
+func startLimited(cmd *exec.Cmd, limits localLimits) error {
+	// Ptrace requests must come from the thread which started the process. The thread is
+	// unlocked, not terminated, when the goroutine returns, so Pdeathsig doesn't fire.
+	runtime.LockOSThread()
+	defer runtime.UnlockOSThread()
+
+	err := cmd.Start()
+	if err != nil {
+		return fmt.Errorf("cmd.Start: %w", err)
+	}
+
+	pid := cmd.Process.Pid
+	kill := func() {
+		_ = syscall.Kill(-pid, syscall.SIGKILL)
+		_ = cmd.Wait()
+	}
+
+	var status syscall.WaitStatus
+	_, err = syscall.Wait4(pid, &status, 0, nil)
+	switch {
+	case err != nil:
+		kill()
+		return fmt.Errorf("syscall.Wait4: %w", err)
+	case !status.Stopped():
+		kill()
+		return fmt.Errorf("%w: process didn't stop at exec", errLocalStart)
+	}
+
+	err = setLimits(pid, limits)
+	if err != nil {
+		kill()
+		return fmt.Errorf("setLimits: %w", err)
+	}
+
+	err = syscall.PtraceDetach(pid)
+	if err != nil {
+		kill()
+		return fmt.Errorf("syscall.PtraceDetach: %w", err)
+	}
+
+	return nil
+}
+
+func setLimits(pid int, limits localLimits) error {
+	rlimits := []struct {
+		resource int
+		value    uint64
+	}{
+		{unix.RLIMIT_DATA, limits.memory},
+		{unix.RLIMIT_CPU, limits.cpuTime},
+		{unix.RLIMIT_FSIZE, limits.fileSize},
+		{unix.RLIMIT_NOFILE, limits.openFiles},
+		{unix.RLIMIT_CORE, 0},
+	}
+
+	for _, rlimit := range rlimits {
+		err := unix.Prlimit(pid, rlimit.resource, &unix.Rlimit{Cur: rlimit.value, Max: rlimit.value}, nil)
+		if err != nil {
+			return fmt.Errorf("unix.Prlimit(%d): %w", rlimit.resource, err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,15 @@
+//go:build !linux
+
+package registry
+
+import (
+	"context"
+	"errors"
+	"io"
+)
+
+var errLocalUnsupported = errors.New("local executor is only supported on linux")
+
+func runLocal(_ context.Context, _, _ string, _ []string, _ localLimits, _ []byte, _, _ io.Writer) error {
+	return errLocalUnsupported
+}
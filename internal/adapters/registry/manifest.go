@@ -0,0 +1,133 @@
+package registry
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/easyp-tech/service/internal/core"
+)
+
+// Labels of the plugin manifest, set as image labels or manifest annotations.
+const (
+	// labelPluginConfig is the JSON plugin config used when a plugin is registered without one.
+	labelPluginConfig      = "tech.easyp.plugin.config"
+	labelPluginDescription = "tech.easyp.plugin.description"
+	labelPluginSource      = "tech.easyp.plugin.source"
+	labelPluginLicense     = "tech.easyp.plugin.license"
+	// labelPluginOptions is the JSON list of accepted options.
+	labelPluginOptions = "tech.easyp.plugin.options"
+)
+
+// Standard OCI annotations used when the plugin labels are missing.
+const (
+	labelOCIDescription = "org.opencontainers.image.description"
+	labelOCISource      = "org.opencontainers.image.source"
+	labelOCILicenses    = "org.opencontainers.image.licenses"
+)
+
+var errManifestType = errors.New("unsupported manifest type")
+
+type (
+	// pluginManifest is the metadata of a plugin read from its image, stored as JSON.
+	pluginManifest struct {
+		Description string         `json:"description,omitempty"`
+		Source      string         `json:"source,omitempty"`
+		License     string         `json:"license,omitempty"`
+		Options     []pluginOption `json:"options,omitempty"`
+	}
+
+	// pluginOption is an option accepted in CodeGeneratorRequest.parameter.
+	pluginOption struct {
+		// Name of the option, a trailing * matches any suffix, e.g. "M*".
+		Name        string `json:"name"`
+		Description string `json:"description,omitempty"`
+		// Type is "string" (default), "bool" or "int".
+		Type string `json:"type,omitempty"`
+		// Values are the allowed values, any value is allowed when empty.
+		Values  []string `json:"values,omitempty"`
+		Default string   `json:"default,omitempty"`
+	}
+)
+
+// parseManifest reads the plugin manifest from image labels.
+func parseManifest(labels map[string]string) (pluginManifest, error) {
+	m := pluginManifest{
+		Description: firstLabel(labels, labelPluginDescription, labelOCIDescription),
+		Source:      firstLabel(labels, labelPluginSource, labelOCISource),
+		License:     firstLabel(labels, labelPluginLicense, labelOCILicenses),
+	}
+
+	if options := labels[labelPluginOptions]; options != "" {
+		err := json.Unmarshal([]byte(options), &m.Options)
+		if err != nil {
+			return pluginManifest{}, fmt.Errorf("%w: label %s: %s", core.ErrInvalidPluginConfig, labelPluginOptions, err)
+		}
+	}
+
+	for _, option := range m.Options {
+		err := option.info().Check()
+		if err != nil {
+			return pluginManifest{}, fmt.Errorf("%w: label %s: %w", core.ErrInvalidPluginConfig, labelPluginOptions, err)
+		}
+	}
+
+	return m, nil
+}
+
+func firstLabel(labels map[string]string, keys ...string) string {
+	for _, key := range keys {
+		if labels[key] != "" {
+			return labels[key]
+		}
+	}
+
+	return ""
+}
+
+// Scan implements sql.Scanner.
+func (m *pluginManifest) Scan(src any) error {
+	switch src := src.(type) {
+	case nil:
+		*m = pluginManifest{}
+
+		return nil
+	case []byte:
+		return json.Unmarshal(src, m)
+	case string:
+		return json.Unmarshal([]byte(src), m)
+	default:
+		return fmt.Errorf("%w: %T", errManifestType, src)
+	}
+}
+
+// Value implements driver.Valuer.
+func (m pluginManifest) Value() (driver.Value, error) {
+	return json.Marshal(m)
+}
+
+// info converts the manifest to the core type.
+func (m *pluginManifest) info() core.PluginManifest {
+	info := core.PluginManifest{
+		Description: m.Description,
+		Source:      m.Source,
+		License:     m.License,
+		Options:     make([]core.PluginOption, len(m.Options)),
+	}
+	for i, option := range m.Options {
+		info.Options[i] = option.info()
+	}
+
+	return info
+}
+
+func (o pluginOption) info() core.PluginOption {
+	return core.PluginOption{
+		Name:        o.Name,
+		Description: o.Description,
+		Type:        o.Type,
+		Values:      o.Values,
+		Default:     o.Default,
+	}
+}
@@ -0,0 +1,70 @@
+package registry
+
+import (
+	"os"
+	"path/filepath"
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// dockerfileLabel matches tech.easyp labels of the registry Dockerfiles, quoted with either quote.
+var dockerfileLabel = regexp.MustCompile(`(tech\.easyp\.plugin\.[a-z_]+)=(?:"([^"]*)"|'([^']*)')`)
+
+// TestParseManifestDockerfiles keeps the images of the registry directory registrable.
+func TestParseManifestDockerfiles(t *testing.T) {
+	t.Parallel()
+
+	files, err := filepath.Glob("../../../registry/*/*/*/Dockerfile")
+	require.NoError(t, err)
+	require.NotEmpty(t, files)
+
+	for _, file := range files {
+		t.Run(file, func(t *testing.T) {
+			t.Parallel()
+
+			content, err := os.ReadFile(file)
+			require.NoError(t, err)
+
+			labels := make(map[string]string)
+			for _, match := range dockerfileLabel.FindAllStringSubmatch(string(content), -1) {
+				labels[match[1]] = match[2] + match[3]
+			}
+
+			_, err = parseManifest(labels)
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestParseManifestOptions(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		options string
+		wantErr bool
+	}{
+		"plain":        {options: `[{"name":"paths","values":["import","source_relative"]}]`},
+		"wildcard":     {options: `[{"name":"M*"},{"name":"apilevelM*"}]`},
+		"typed":        {options: `[{"name":"annotate_code","type":"bool","default":"true"}]`},
+		"inner star":   {options: `[{"name":"M*x"}]`, wantErr: true},
+		"only star":    {options: `[{"name":"*"}]`, wantErr: true},
+		"bad default":  {options: `[{"name":"paths","values":["import"],"default":"source_relative"}]`, wantErr: true},
+		"unknown type": {options: `[{"name":"paths","type":"float"}]`, wantErr: true},
+		"not json":     {options: `paths`, wantErr: true},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := parseManifest(map[string]string{labelPluginOptions: tt.options})
+			if tt.wantErr {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
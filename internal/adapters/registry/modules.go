@@ -0,0 +1,275 @@
+package registry
+
+import (
+	"cmp"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/descriptorpb"
+
+	"github.com/easyp-tech/service/internal/core"
+)
+
+var _ core.ModuleStore = &Registry{}
+
+// moduleFileExt is the extension of module descriptor sets in the modules directory.
+const moduleFileExt = ".binpb"
+
+type (
+	// module is a stored module version.
+	module struct {
+		Name      string    `db:"name"`
+		Version   string    `db:"version"`
+		Files     int       `db:"files"`
+		CreatedAt time.Time `db:"created_at"`
+	}
+
+	// moduleFile is a file of a stored module, its content is in the descriptors table.
+	moduleFile struct {
+		Name     string `db:"name"`
+		Version  string `db:"version"`
+		Position int    `db:"position"`
+		Path     string `db:"path"`
+		Hash     string `db:"hash"`
+	}
+)
+
+// PutModule implements core.ModuleStore.
+func (r *Registry) PutModule(ctx context.Context, name, version string, files []*descriptorpb.FileDescriptorProto) (*core.ModuleInfo, error) {
+	_, err := r.dirModule(name, version)
+	switch {
+	case err == nil:
+		return nil, fmt.Errorf("%w: module %s@%s is in the modules directory", core.ErrAlreadyExists, name, version)
+	case !errors.Is(err, core.ErrNotFound):
+		return nil, fmt.Errorf("r.dirModule: %w", err)
+	}
+
+	descriptors := make([]descriptor, len(files))
+	rows := make([]moduleFile, len(files))
+	for i, file := range files {
+		content, err := proto.MarshalOptions{Deterministic: true}.Marshal(file)
+		if err != nil {
+			return nil, fmt.Errorf("proto.Marshal: %w", err)
+		}
+
+		hash, err := core.DescriptorHash(file)
+		if err != nil {
+			return nil, fmt.Errorf("core.DescriptorHash: %w", err)
+		}
+
+		descriptors[i] = descriptor{Hash: hash, Content: content}
+		rows[i] = moduleFile{Name: name, Version: version, Position: i, Path: file.GetName(), Hash: hash}
+	}
+
+	info := &core.ModuleInfo{Name: name, Version: version, Files: len(files)}
+	err = r.sql.Tx(ctx, nil, func(tx *sqlx.Tx) error {
+		query := "insert into modules (name, version) values ($1, $2) on conflict (name, version) do nothing returning created_at"
+
+		err := tx.GetContext(ctx, &info.CreatedAt, query, name, version)
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			return fmt.Errorf("%w: module %s@%s", core.ErrAlreadyExists, name, version)
+		case err != nil:
+			return fmt.Errorf("tx.GetContext: %w", err)
+		}
+
+		query = "insert into descriptors (hash, content) values (:hash, :content) on conflict (hash) do nothing"
+
+		_, err = tx.NamedExecContext(ctx, query, descriptors)
+		if err != nil {
+			return fmt.Errorf("tx.NamedExecContext: %w", err)
+		}
+
+		query = `insert into module_files (name, version, position, path, hash)
+			values (:name, :version, :position, :path, :hash)`
+
+		_, err = tx.NamedExecContext(ctx, query, rows)
+		if err != nil {
+			return fmt.Errorf("tx.NamedExecContext: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("sql.Tx: %w", err)
+	}
+
+	return info, nil
+}
+
+// Module implements core.ModuleStore.
+// Stored modules take precedence over the modules directory, files of found modules are cached.
+func (r *Registry) Module(ctx context.Context, name, version string) ([]*descriptorpb.FileDescriptorProto, error) {
+	key := name + "@" + version
+	if files, ok := r.modules.Load(key); ok {
+		return files.([]*descriptorpb.FileDescriptorProto), nil //nolint:forcetypeassert // Only files are stored.
+	}
+
+	files, err := r.storedModule(ctx, name, version)
+	if errors.Is(err, core.ErrNotFound) {
+		files, err = r.dirModule(name, version)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("module %s: %w", key, err)
+	}
+
+	r.modules.Store(key, files)
+
+	return files, nil
+}
+
+func (r *Registry) storedModule(ctx context.Context, name, version string) ([]*descriptorpb.FileDescriptorProto, error) {
+	var contents [][]byte
+	err := r.sql.NoTx(func(d *sqlx.DB) error {
+		query := `select d.content from module_files f join descriptors d on d.hash = f.hash
+			where f.name = $1 and f.version = $2 order by f.position`
+
+		err := d.SelectContext(ctx, &contents, query, name, version)
+		if err != nil {
+			return fmt.Errorf("d.SelectContext: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("sql.NoTx: %w", err)
+	}
+
+	if len(contents) == 0 {
+		return nil, core.ErrNotFound
+	}
+
+	files := make([]*descriptorpb.FileDescriptorProto, len(contents))
+	for i, content := range contents {
+		files[i] = &descriptorpb.FileDescriptorProto{}
+		err := proto.Unmarshal(content, files[i])
+		if err != nil {
+			return nil, fmt.Errorf("proto.Unmarshal: %w", err)
+		}
+	}
+
+	return files, nil
+}
+
+// dirModule reads <modules dir>/<name>/<version>.binpb, a binary FileDescriptorSet.
+func (r *Registry) dirModule(name, version string) ([]*descriptorpb.FileDescriptorProto, error) {
+	if r.modulesDir == "" {
+		return nil, core.ErrNotFound
+	}
+
+	data, err := os.ReadFile(filepath.Join(r.modulesDir, filepath.FromSlash(name), version+moduleFileExt))
+	switch {
+	case errors.Is(err, fs.ErrNotExist):
+		return nil, core.ErrNotFound
+	case err != nil:
+		return nil, fmt.Errorf("os.ReadFile: %w", err)
+	}
+
+	set := &descriptorpb.FileDescriptorSet{}
+	err = proto.Unmarshal(data, set)
+	if err != nil {
+		return nil, fmt.Errorf("proto.Unmarshal: %w", err)
+	}
+
+	return set.File, nil
+}
+
+// Modules implements core.ModuleStore.
+func (r *Registry) Modules(ctx context.Context) ([]core.ModuleInfo, error) {
+	var rows []module
+	err := r.sql.NoTx(func(d *sqlx.DB) error {
+		query := `select m.name, m.version, count(f.path) as files, m.created_at
+			from modules m left join module_files f on f.name = m.name and f.version = m.version
+			group by m.name, m.version, m.created_at`
+
+		err := d.SelectContext(ctx, &rows, query)
+		if err != nil {
+			return fmt.Errorf("d.SelectContext: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("sql.NoTx: %w", err)
+	}
+
+	modules := make([]core.ModuleInfo, len(rows))
+	for i, row := range rows {
+		modules[i] = core.ModuleInfo{Name: row.Name, Version: row.Version, Files: row.Files, CreatedAt: row.CreatedAt}
+	}
+
+	dirModules, err := r.dirModules()
+	if err != nil {
+		return nil, fmt.Errorf("r.dirModules: %w", err)
+	}
+
+	modules = append(modules, dirModules...)
+	slices.SortFunc(modules, func(a, b core.ModuleInfo) int {
+		return cmp.Or(cmp.Compare(a.Name, b.Name), cmp.Compare(a.Version, b.Version))
+	})
+
+	return modules, nil
+}
+
+// dirModules lists the modules directory, CreatedAt is the modification time of the file.
+func (r *Registry) dirModules() ([]core.ModuleInfo, error) {
+	if r.modulesDir == "" {
+		return nil, nil
+	}
+
+	var modules []core.ModuleInfo
+	err := filepath.WalkDir(r.modulesDir, func(file string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if entry.IsDir() || !strings.HasSuffix(file, moduleFileExt) {
+			return nil
+		}
+
+		rel, err := filepath.Rel(r.modulesDir, file)
+		if err != nil {
+			return fmt.Errorf("filepath.Rel: %w", err)
+		}
+
+		name, version := path.Split(strings.TrimSuffix(filepath.ToSlash(rel), moduleFileExt))
+		if name == "" {
+			return nil
+		}
+
+		files, err := r.dirModule(strings.TrimSuffix(name, "/"), version)
+		if err != nil {
+			return fmt.Errorf("r.dirModule: %w", err)
+		}
+
+		stat, err := entry.Info()
+		if err != nil {
+			return fmt.Errorf("entry.Info: %w", err)
+		}
+
+		modules = append(modules, core.ModuleInfo{
+			Name:      strings.TrimSuffix(name, "/"),
+			Version:   version,
+			Files:     len(files),
+			CreatedAt: stat.ModTime(),
+		})
+
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("filepath.WalkDir: %w", err)
+	}
+
+	return modules, nil
+}
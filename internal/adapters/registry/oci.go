@@ -0,0 +1,568 @@
+package registry
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"net"
+	"net/http"
+	"net/url"
+	"runtime"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// OCI media types.
+const (
+	mediaTypeOCIManifest    = "application/vnd.oci.image.manifest.v1+json"
+	mediaTypeDockerManifest = "application/vnd.docker.distribution.manifest.v2+json"
+	mediaTypeOCIIndex       = "application/vnd.oci.image.index.v1+json"
+	mediaTypeDockerList     = "application/vnd.docker.distribution.manifest.list.v2+json"
+)
+
+// Docker Hub serves the API on another host than its image references.
+const (
+	dockerHubDomain = "docker.io"
+	dockerHubAPI    = "registry-1.docker.io"
+)
+
+const defaultTokenLifetime = time.Minute
+
+const catalogPageSize = 1000
+
+// Limits of the downloaded documents.
+const (
+	maxManifestSize = 4 << 20
+	maxBlobSize     = 256 << 20
+)
+
+var (
+	errRegistryResponse = errors.New("unexpected registry response")
+	errRegistryNotFound = errors.New("not found in registry")
+	errDigestMismatch   = errors.New("digest mismatch")
+)
+
+type (
+	// ociClient is a minimal client of the OCI distribution API of a single registry.
+	// It authenticates with basic credentials or the bearer token flow when challenged.
+	ociClient struct {
+		client   *http.Client
+		domain   string
+		username string
+		password string
+		// insecure uses plain HTTP.
+		insecure bool
+
+		mu     sync.Mutex
+		tokens map[string]bearerToken
+	}
+
+	// bearerToken is a cached token of a challenge.
+	bearerToken struct {
+		token   string
+		expires time.Time
+	}
+
+	// ociDescriptor describes content stored in the registry.
+	ociDescriptor struct {
+		MediaType   string            `json:"mediaType"`
+		Digest      string            `json:"digest"`
+		Size        int64             `json:"size"`
+		Annotations map[string]string `json:"annotations,omitempty"`
+	}
+
+	// ociIndex is an image index, also returned by the referrers API.
+	ociIndex struct {
+		SchemaVersion int                  `json:"schemaVersion"`
+		MediaType     string               `json:"mediaType,omitempty"`
+		Manifests     []ociIndexDescriptor `json:"manifests"`
+	}
+
+	// ociIndexDescriptor describes a manifest of an index.
+	ociIndexDescriptor struct {
+		ociDescriptor
+		ArtifactType string       `json:"artifactType,omitempty"`
+		Platform     *ociPlatform `json:"platform,omitempty"`
+	}
+
+	// ociPlatform is the platform of an index manifest.
+	ociPlatform struct {
+		Architecture string `json:"architecture"`
+		OS           string `json:"os"`
+	}
+
+	// ociImageConfig is the part of the image config blob with labels.
+	ociImageConfig struct {
+		Config struct {
+			Labels map[string]string `json:"Labels"`
+		} `json:"config"`
+	}
+
+	// ociManifest is an image manifest.
+	ociManifest struct {
+		SchemaVersion int               `json:"schemaVersion"`
+		MediaType     string            `json:"mediaType,omitempty"`
+		ArtifactType  string            `json:"artifactType,omitempty"`
+		Config        ociDescriptor     `json:"config"`
+		Layers        []ociDescriptor   `json:"layers"`
+		Annotations   map[string]string `json:"annotations,omitempty"`
+	}
+)
+
+// manifest returns the manifest of repository by tag or digest and its digest.
+func (c *ociClient) manifest(ctx context.Context, repository, reference string) (*ociManifest, string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.baseURL()+"/v2/"+repository+"/manifests/"+reference, http.NoBody)
+	if err != nil {
+		return nil, "", fmt.Errorf("http.NewRequestWithContext: %w", err)
+	}
+	req.Header.Set("Accept", mediaTypeOCIManifest+", "+mediaTypeDockerManifest)
+
+	body, err := c.do(req, maxManifestSize)
+	if err != nil {
+		return nil, "", fmt.Errorf("c.do: %w", err)
+	}
+
+	manifest := &ociManifest{}
+	err = json.Unmarshal(body, manifest)
+	if err != nil {
+		return nil, "", fmt.Errorf("json.Unmarshal: %w", err)
+	}
+
+	return manifest, digestOf(body), nil
+}
+
+// resolve returns the digest of the manifest or index which the tag points to.
+func (c *ociClient) resolve(ctx context.Context, repository, tag string) (string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.baseURL()+"/v2/"+repository+"/manifests/"+tag, http.NoBody)
+	if err != nil {
+		return "", fmt.Errorf("http.NewRequestWithContext: %w", err)
+	}
+	req.Header.Set("Accept", strings.Join([]string{mediaTypeOCIManifest, mediaTypeDockerManifest, mediaTypeOCIIndex, mediaTypeDockerList}, ", "))
+
+	body, err := c.do(req, maxManifestSize)
+	if err != nil {
+		return "", fmt.Errorf("c.do: %w", err)
+	}
+
+	return digestOf(body), nil
+}
+
+// referrers returns manifests referring to the digest with the artifact type.
+// Registries without the referrers API are queried by the fallback tag schema.
+func (c *ociClient) referrers(ctx context.Context, repository, digest, artifactType string) ([]ociIndexDescriptor, error) {
+	query := url.Values{"artifactType": []string{artifactType}}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.baseURL()+"/v2/"+repository+"/referrers/"+digest+"?"+query.Encode(), http.NoBody)
+	if err != nil {
+		return nil, fmt.Errorf("http.NewRequestWithContext: %w", err)
+	}
+	req.Header.Set("Accept", mediaTypeOCIIndex)
+
+	body, err := c.do(req, maxManifestSize)
+	if errors.Is(err, errRegistryNotFound) {
+		req, err = http.NewRequestWithContext(ctx, http.MethodGet, c.baseURL()+"/v2/"+repository+"/manifests/"+referrersTag(digest), http.NoBody)
+		if err != nil {
+			return nil, fmt.Errorf("http.NewRequestWithContext: %w", err)
+		}
+		req.Header.Set("Accept", mediaTypeOCIIndex)
+
+		body, err = c.do(req, maxManifestSize)
+	}
+	switch {
+	case errors.Is(err, errRegistryNotFound):
+		return nil, nil
+	case err != nil:
+		return nil, fmt.Errorf("c.do: %w", err)
+	}
+
+	index := &ociIndex{}
+	err = json.Unmarshal(body, index)
+	if err != nil {
+		return nil, fmt.Errorf("json.Unmarshal: %w", err)
+	}
+
+	referrers := make([]ociIndexDescriptor, 0, len(index.Manifests))
+	for _, m := range index.Manifests {
+		if m.ArtifactType == artifactType {
+			referrers = append(referrers, m)
+		}
+	}
+
+	return referrers, nil
+}
+
+// catalog returns all repositories of the registry.
+func (c *ociClient) catalog(ctx context.Context) ([]string, error) {
+	var page struct {
+		Repositories []string `json:"repositories"`
+	}
+
+	var repositories []string
+	err := c.pages(ctx, "/v2/_catalog?n="+strconv.Itoa(catalogPageSize), &page, func() {
+		repositories = append(repositories, page.Repositories...)
+		page.Repositories = nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("c.pages: %w", err)
+	}
+
+	return repositories, nil
+}
+
+// tags returns all tags of the repository.
+func (c *ociClient) tags(ctx context.Context, repository string) ([]string, error) {
+	var page struct {
+		Tags []string `json:"tags"`
+	}
+
+	var tags []string
+	err := c.pages(ctx, "/v2/"+repository+"/tags/list?n="+strconv.Itoa(catalogPageSize), &page, func() {
+		tags = append(tags, page.Tags...)
+		page.Tags = nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("c.pages: %w", err)
+	}
+
+	return tags, nil
+}
+
+// pages decodes every page of a paginated list into page and calls collect after each one.
+// The next page is taken from the Link header.
+func (c *ociClient) pages(ctx context.Context, path string, page any, collect func()) error {
+	next := c.baseURL() + path
+	for next != "" {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, next, http.NoBody)
+		if err != nil {
+			return fmt.Errorf("http.NewRequestWithContext: %w", err)
+		}
+
+		resp, err := c.send(req)
+		if err != nil {
+			return fmt.Errorf("c.send: %w", err)
+		}
+
+		if resp.StatusCode != http.StatusOK {
+			_ = resp.Body.Close()
+
+			return fmt.Errorf("%w: %s %s: %s", errRegistryResponse, req.Method, req.URL.Path, resp.Status)
+		}
+
+		err = json.NewDecoder(io.LimitReader(resp.Body, maxManifestSize)).Decode(page)
+		_ = resp.Body.Close()
+		if err != nil {
+			return fmt.Errorf("json.Decode: %w", err)
+		}
+
+		collect()
+
+		next, err = nextPage(req.URL, resp.Header.Get("Link"))
+		if err != nil {
+			return fmt.Errorf("nextPage: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// nextPage returns the URL of the rel="next" link, e.g. </v2/_catalog?last=b&n=100>; rel="next".
+func nextPage(current *url.URL, link string) (string, error) {
+	target, params, ok := strings.Cut(link, ";")
+	if !ok || !strings.Contains(strings.ReplaceAll(params, " ", ""), `rel="next"`) {
+		return "", nil
+	}
+
+	next, err := current.Parse(strings.Trim(strings.TrimSpace(target), "<>"))
+	if err != nil {
+		return "", fmt.Errorf("url.Parse: %w", err)
+	}
+
+	return next.String(), nil
+}
+
+// labels returns the manifest annotations merged over the config labels of the image.
+// The manifest of the current platform, or the first one, is used for image indexes.
+func (c *ociClient) labels(ctx context.Context, repository, reference string) (map[string]string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.baseURL()+"/v2/"+repository+"/manifests/"+reference, http.NoBody)
+	if err != nil {
+		return nil, fmt.Errorf("http.NewRequestWithContext: %w", err)
+	}
+	req.Header.Set("Accept", strings.Join([]string{mediaTypeOCIManifest, mediaTypeDockerManifest, mediaTypeOCIIndex, mediaTypeDockerList}, ", "))
+
+	body, err := c.do(req, maxManifestSize)
+	if err != nil {
+		return nil, fmt.Errorf("c.do: %w", err)
+	}
+
+	index := &ociIndex{}
+	err = json.Unmarshal(body, index)
+	if err != nil {
+		return nil, fmt.Errorf("json.Unmarshal: %w", err)
+	}
+
+	if len(index.Manifests) > 0 {
+		digest := index.Manifests[0].Digest
+		for _, m := range index.Manifests {
+			if m.Platform != nil && m.Platform.OS == runtime.GOOS && m.Platform.Architecture == runtime.GOARCH {
+				digest = m.Digest
+				break
+			}
+		}
+
+		return c.labels(ctx, repository, digest)
+	}
+
+	manifest := &ociManifest{}
+	err = json.Unmarshal(body, manifest)
+	if err != nil {
+		return nil, fmt.Errorf("json.Unmarshal: %w", err)
+	}
+
+	labels := make(map[string]string)
+	if manifest.Config.Digest != "" {
+		blob, err := c.blob(ctx, repository, manifest.Config.Digest)
+		if err != nil {
+			return nil, fmt.Errorf("c.blob: %w", err)
+		}
+
+		config := &ociImageConfig{}
+		// Configs of artifacts are not image configs, they just have no labels.
+		if json.Unmarshal(blob, config) == nil {
+			maps.Copy(labels, config.Config.Labels)
+		}
+	}
+
+	maps.Copy(labels, manifest.Annotations)
+
+	return labels, nil
+}
+
+// referrersTag returns the tag of the referrers fallback schema, e.g. sha256-<hex>.
+func referrersTag(digest string) string {
+	return strings.Replace(digest, ":", "-", 1)
+}
+
+// blob downloads the blob and verifies its digest.
+func (c *ociClient) blob(ctx context.Context, repository, digest string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.baseURL()+"/v2/"+repository+"/blobs/"+digest, http.NoBody)
+	if err != nil {
+		return nil, fmt.Errorf("http.NewRequestWithContext: %w", err)
+	}
+
+	body, err := c.do(req, maxBlobSize)
+	if err != nil {
+		return nil, fmt.Errorf("c.do: %w", err)
+	}
+
+	if digestOf(body) != digest {
+		return nil, fmt.Errorf("%w: %s", errDigestMismatch, digest)
+	}
+
+	return body, nil
+}
+
+func (c *ociClient) do(req *http.Request, limit int64) ([]byte, error) {
+	resp, err := c.send(req)
+	if err != nil {
+		return nil, fmt.Errorf("c.send: %w", err)
+	}
+	defer resp.Body.Close() //nolint:errcheck // Body is fully read.
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, fmt.Errorf("%w: %s %s", errRegistryNotFound, req.Method, req.URL.Path)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%w: %s %s: %s", errRegistryResponse, req.Method, req.URL.Path, resp.Status)
+	}
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, limit))
+	if err != nil {
+		return nil, fmt.Errorf("io.ReadAll: %w", err)
+	}
+
+	return body, nil
+}
+
+// send sends the request and repeats it once with credentials if the registry asks for them.
+func (c *ociClient) send(req *http.Request) (*http.Response, error) {
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("c.client.Do: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusUnauthorized {
+		return resp, nil
+	}
+
+	challenge := resp.Header.Get("WWW-Authenticate")
+	_ = resp.Body.Close()
+
+	retry := req.Clone(req.Context())
+	scheme, params := parseChallenge(challenge)
+	switch scheme {
+	case "basic":
+		retry.SetBasicAuth(c.username, c.password)
+	case "bearer":
+		token, err := c.token(req.Context(), params)
+		if err != nil {
+			return nil, fmt.Errorf("c.token: %w", err)
+		}
+
+		retry.Header.Set("Authorization", "Bearer "+token)
+	default:
+		return nil, fmt.Errorf("%w: %s %s: unsupported challenge %q", errRegistryResponse, req.Method, req.URL.Path, challenge)
+	}
+
+	resp, err = c.client.Do(retry)
+	if err != nil {
+		return nil, fmt.Errorf("c.client.Do: %w", err)
+	}
+
+	return resp, nil
+}
+
+// token returns a cached or a new token of the bearer challenge.
+func (c *ociClient) token(ctx context.Context, params map[string]string) (string, error) {
+	realm := params["realm"]
+	if realm == "" {
+		return "", fmt.Errorf("%w: bearer challenge without realm", errRegistryResponse)
+	}
+
+	query := url.Values{}
+	for _, key := range []string{"service", "scope"} {
+		if params[key] != "" {
+			query.Set(key, params[key])
+		}
+	}
+
+	tokenURL := realm + "?" + query.Encode()
+
+	c.mu.Lock()
+	cached, ok := c.tokens[tokenURL]
+	c.mu.Unlock()
+	if ok && time.Now().Before(cached.expires) {
+		return cached.token, nil
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, tokenURL, http.NoBody)
+	if err != nil {
+		return "", fmt.Errorf("http.NewRequestWithContext: %w", err)
+	}
+
+	if c.username != "" {
+		req.SetBasicAuth(c.username, c.password)
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("c.client.Do: %w", err)
+	}
+	defer resp.Body.Close() //nolint:errcheck // Body is fully read.
+
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("%w: token %s: %s", errRegistryResponse, realm, resp.Status)
+	}
+
+	var body struct {
+		Token       string `json:"token"`
+		AccessToken string `json:"access_token"`
+		ExpiresIn   int    `json:"expires_in"`
+	}
+	err = json.NewDecoder(io.LimitReader(resp.Body, maxManifestSize)).Decode(&body)
+	if err != nil {
+		return "", fmt.Errorf("json.Decode: %w", err)
+	}
+
+	token := body.Token
+	if token == "" {
+		token = body.AccessToken
+	}
+
+	expiresIn := time.Duration(body.ExpiresIn) * time.Second
+	if expiresIn <= 0 {
+		expiresIn = defaultTokenLifetime
+	}
+
+	c.mu.Lock()
+	if c.tokens == nil {
+		c.tokens = make(map[string]bearerToken)
+	}
+	// Renew the token a bit earlier to not send an expired one.
+	c.tokens[tokenURL] = bearerToken{token: token, expires: time.Now().Add(expiresIn * 9 / 10)}
+	c.mu.Unlock()
+
+	return token, nil
+}
+
+// parseChallenge parses the WWW-Authenticate header, e.g.
+// Bearer realm="https://auth.example.com/token",service="registry",scope="repository:a/b:pull".
+func parseChallenge(header string) (string, map[string]string) {
+	scheme, rest, _ := strings.Cut(strings.TrimSpace(header), " ")
+	params := make(map[string]string)
+
+	for rest != "" {
+		var pair string
+		key, value, ok := strings.Cut(rest, "=")
+		if !ok {
+			break
+		}
+
+		key = strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(key), ","))
+		value = strings.TrimSpace(value)
+		if strings.HasPrefix(value, `"`) {
+			end := strings.Index(value[1:], `"`)
+			if end == -1 {
+				break
+			}
+
+			pair, rest = value[1:end+1], value[end+2:]
+		} else {
+			pair, rest, _ = strings.Cut(value, ",")
+		}
+
+		params[strings.ToLower(key)] = pair
+	}
+
+	return strings.ToLower(scheme), params
+}
+
+// baseURL returns the base URL of the registry.
+// Plain HTTP is used for insecure registries and registries on the loopback interface.
+func (c *ociClient) baseURL() string {
+	if strings.Contains(c.domain, "://") {
+		return strings.TrimSuffix(c.domain, "/")
+	}
+
+	if c.insecure {
+		return "http://" + c.domain
+	}
+
+	if c.domain == dockerHubDomain {
+		return "https://" + dockerHubAPI
+	}
+
+	host, _, err := net.SplitHostPort(c.domain)
+	if err != nil {
+		host = c.domain
+	}
+
+	ip := net.ParseIP(host)
+	if host == "localhost" || (ip != nil && ip.IsLoopback()) {
+		return "http://" + c.domain
+	}
+
+	return "https://" + c.domain
+}
+
+func digestOf(data []byte) string {
+	sum := sha256.Sum256(data)
+
+	return "sha256:" + hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,130 @@
+package registry
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/gofrs/uuid/v5"
+	"github.com/jmoiron/sqlx"
+
+	"github.com/easyp-tech/service/internal/core"
+)
+
+// ParametersConfig is enforced on CodeGeneratorRequest.parameter of every run.
+type ParametersConfig struct {
+	// Defaults are added when the client doesn't set the option.
+	Defaults map[string]string `json:"defaults,omitempty"`
+	// Locked options can't be changed by clients: the option is set to the value,
+	// an empty value forbids the option.
+	Locked map[string]string `json:"locked,omitempty"`
+}
+
+// apply merges the defaults and the locked options into the comma separated key[=value] parameter.
+// Client options keep their order, enforced ones are appended sorted by key.
+func (c *ParametersConfig) apply(parameter string) (string, error) {
+	var (
+		items    []string
+		problems []string
+		present  = make(map[string]bool)
+	)
+
+	for _, item := range strings.Split(parameter, ",") {
+		if item == "" {
+			continue
+		}
+
+		key, value, hasValue := strings.Cut(item, "=")
+		present[key] = true
+
+		locked, ok := c.Locked[key]
+		switch {
+		case ok && locked == "":
+			problems = append(problems, fmt.Sprintf("option %q is not allowed", key))
+		case ok && (!hasValue || value != locked):
+			problems = append(problems, fmt.Sprintf("option %q is locked to %q", key, locked))
+		}
+
+		items = append(items, item)
+	}
+
+	if len(problems) > 0 {
+		return "", fmt.Errorf("%w: parameter: %s", core.ErrInvalidArgument, strings.Join(problems, "; "))
+	}
+
+	for _, key := range slices.Sorted(maps.Keys(c.Locked)) {
+		if !present[key] && c.Locked[key] != "" {
+			items = append(items, key+"="+c.Locked[key])
+		}
+	}
+
+	for _, key := range slices.Sorted(maps.Keys(c.Defaults)) {
+		if _, locked := c.Locked[key]; !present[key] && !locked {
+			items = append(items, key+"="+c.Defaults[key])
+		}
+	}
+
+	return strings.Join(items, ","), nil
+}
+
+// check validates the options and their values, options must be declared by the manifest if it has any.
+func (c *ParametersConfig) check(manifest *pluginManifest) []string {
+	var problems []string
+
+	options := manifest.info().Options
+	for _, set := range []struct {
+		kind   string
+		values map[string]string
+	}{{"default", c.Defaults}, {"locked", c.Locked}} {
+		kind, values := set.kind, set.values
+		for _, key := range slices.Sorted(maps.Keys(values)) {
+			value := values[key]
+			if key == "" || strings.ContainsAny(key, ",=") || strings.Contains(value, ",") {
+				problems = append(problems, fmt.Sprintf("%s option %q: the key can't be empty or contain ',' or '=', the value can't contain ','", kind, key))
+				continue
+			}
+
+			if kind == "locked" && value == "" {
+				continue
+			}
+
+			err := core.ValidateParameter(options, key+"="+value)
+			if err != nil {
+				problems = append(problems, fmt.Sprintf("%s option %q: %s", kind, key, err))
+			}
+		}
+	}
+
+	return problems
+}
+
+// Parameter implements core.Plugin.
+func (p *plugin) Parameter(parameter string) (string, bool, error) {
+	if p.pluginConfig.Parameters == nil {
+		return parameter, false, nil
+	}
+
+	effective, err := p.pluginConfig.Parameters.apply(parameter)
+	if err != nil {
+		return "", true, fmt.Errorf("p.pluginConfig.Parameters.apply: %w", err)
+	}
+
+	return effective, true, nil
+}
+
+// AuditParameter implements core.Registry.
+func (r *Registry) AuditParameter(ctx context.Context, pluginID uuid.UUID, requested, effective string) error {
+	return r.sql.NoTx(func(d *sqlx.DB) error {
+		err := audit(ctx, d, pluginID, auditEffectiveParameter, map[string]string{
+			"requested": requested,
+			"effective": effective,
+		})
+		if err != nil {
+			return fmt.Errorf("audit: %w", err)
+		}
+
+		return nil
+	})
+}
@@ -0,0 +1,49 @@
+package registry
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/easyp-tech/service/internal/core"
+)
+
+func TestParametersApply(t *testing.T) {
+	t.Parallel()
+
+	cfg := &ParametersConfig{
+		Defaults: map[string]string{"paths": "source_relative", "lang": "java", "module": "x"},
+		Locked:   map[string]string{"lang": "go", "plugins": "", "module": "example.com"},
+	}
+
+	tests := map[string]struct {
+		parameter string
+		want      string
+		wantErr   bool
+	}{
+		"empty":                {parameter: "", want: "lang=go,module=example.com,paths=source_relative"},
+		"client option kept":   {parameter: "paths=import", want: "paths=import,lang=go,module=example.com"},
+		"client order kept":    {parameter: "b=1,a,paths=import", want: "b=1,a,paths=import,lang=go,module=example.com"},
+		"locked over default":  {parameter: "b=1", want: "b=1,lang=go,module=example.com,paths=source_relative"},
+		"locked value allowed": {parameter: "module=example.com", want: "module=example.com,lang=go,paths=source_relative"},
+		"locked changed":       {parameter: "lang=java", wantErr: true},
+		"locked without value": {parameter: "lang", wantErr: true},
+		"forbidden":            {parameter: "plugins=grpc", wantErr: true},
+		"forbidden flag":       {parameter: "plugins", wantErr: true},
+		"empty items":          {parameter: ",paths=import,", want: "paths=import,lang=go,module=example.com"},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := cfg.apply(tt.parameter)
+			if tt.wantErr {
+				require.ErrorIs(t, err, core.ErrInvalidArgument)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, tt.want, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,234 @@
+package registry
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os/exec"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+var (
+	_ executor       = &podmanExecutor{}
+	_ puller         = &podmanExecutor{}
+	_ imageCollector = &podmanExecutor{}
+)
+
+var errUnsupportedOption = errors.New("unsupported plugin option")
+
+type (
+	// podmanExecutor runs plugins as containers of a (rootless) Podman.
+	podmanExecutor struct {
+		binary     string
+		seccompDir string
+		// domain is the default registry, only its images are collected.
+		domain string
+		// env points the engine to credentials of the upstream registries.
+		env []string
+
+		mu   sync.Mutex
+		info *podmanInfo
+	}
+
+	// podmanInfo is the part of `podman info` output describing host capabilities.
+	podmanInfo struct {
+		Host struct {
+			CgroupVersion     string   `json:"cgroupVersion"`
+			CgroupControllers []string `json:"cgroupControllers"`
+			Security          struct {
+				Rootless bool `json:"rootless"`
+			} `json:"security"`
+			// RootlessNetworkCmd is the default network of rootless containers, "pasta" or "slirp4netns".
+			RootlessNetworkCmd string `json:"rootlessNetworkCmd"`
+		} `json:"host"`
+	}
+)
+
+// execute implements executor.
+func (e *podmanExecutor) execute(ctx context.Context, p *plugin, input []byte) ([]byte, error) {
+	imageName := p.image()
+
+	dockerConfig := p.pluginConfig.Docker
+	if dockerConfig == nil {
+		dockerConfig = &DockerConfig{}
+	}
+
+	info, err := e.hostInfo(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("e.hostInfo: %w", err)
+	}
+
+	args, err := podmanArgs(dockerConfig, info)
+	if err != nil {
+		return nil, fmt.Errorf("podmanArgs: %w", err)
+	}
+
+	security, err := securityArgs(dockerConfig, e.seccompDir)
+	if err != nil {
+		return nil, fmt.Errorf("securityArgs: %w", err)
+	}
+
+	secrets, cleanup, err := secretArgs(p)
+	if err != nil {
+		return nil, fmt.Errorf("secretArgs: %w", err)
+	}
+	defer cleanup()
+
+	args = append(args, security...)
+	args = append(args, secrets...)
+	args = append(args, tlsArgs(p)...)
+	args = append(args, imageName)
+
+	return runContainer(ctx, e.binary, args, e.env, input)
+}
+
+// pull implements puller.
+func (e *podmanExecutor) pull(ctx context.Context, p *plugin) error {
+	return pullImage(ctx, e.binary, e.env, append(tlsArgs(p), p.image())...)
+}
+
+// collect implements imageCollector.
+func (e *podmanExecutor) collect(ctx context.Context, keep map[string]bool) error {
+	return collectImages(ctx, e.binary, e.domain, keep)
+}
+
+// tlsArgs disables TLS verification for images of insecure registries.
+func tlsArgs(p *plugin) []string {
+	if p.ref.upstream != nil && p.ref.upstream.Insecure {
+		return []string{"--tls-verify=false"}
+	}
+
+	return nil
+}
+
+// hostInfo returns cached capabilities of the Podman host.
+func (e *podmanExecutor) hostInfo(ctx context.Context) (*podmanInfo, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if e.info != nil {
+		return e.info, nil
+	}
+
+	output, err := exec.CommandContext(ctx, e.binary, "info", "--format", "json").Output()
+	if err != nil {
+		return nil, fmt.Errorf("podman info: %w", err)
+	}
+
+	info := &podmanInfo{}
+	err = json.Unmarshal(output, info)
+	if err != nil {
+		return nil, fmt.Errorf("json.Unmarshal: %w", err)
+	}
+
+	e.info = info
+
+	return info, nil
+}
+
+// podmanArgs maps DockerConfig to rootless Podman flags.
+// Limits which the host can't enforce are returned as errUnsupportedOption instead of being ignored.
+func podmanArgs(dockerConfig *DockerConfig, info *podmanInfo) ([]string, error) {
+	args := []string{"run", "--rm", "-i"}
+	var unsupported []string
+
+	switch dockerConfig.Network {
+	case "", "none":
+		args = append(args, "--network=none")
+	case "bridge":
+		// Rootless containers can't use the Docker bridge, the user mode network is used instead.
+		switch {
+		case !info.Host.Security.Rootless:
+			args = append(args, "--network=bridge")
+		case info.Host.RootlessNetworkCmd != "":
+			args = append(args, "--network="+info.Host.RootlessNetworkCmd)
+		default:
+			unsupported = append(unsupported, "network: rootless podman doesn't report its user mode network")
+		}
+	default:
+		args = append(args, "--network="+dockerConfig.Network)
+	}
+
+	memory := dockerConfig.Memory
+	if memory == "" {
+		memory = "128m"
+	}
+
+	if reason := info.unsupportedController("memory"); reason != "" {
+		unsupported = append(unsupported, "memory: "+reason)
+	} else {
+		args = append(args, "--memory="+memory)
+	}
+
+	cpus := dockerConfig.CPUs
+	if cpus == "" {
+		cpus = "1.0"
+	}
+
+	if reason := info.unsupportedController("cpu"); reason != "" {
+		unsupported = append(unsupported, "cpus: "+reason)
+	} else {
+		args = append(args, "--cpus="+cpus)
+	}
+
+	if dockerConfig.PidsLimit != 0 {
+		if reason := info.unsupportedController("pids"); reason != "" {
+			unsupported = append(unsupported, "pids_limit: "+reason)
+		} else {
+			args = append(args, "--pids-limit="+strconv.FormatInt(dockerConfig.PidsLimit, 10))
+		}
+	}
+
+	if dockerConfig.User != "" {
+		args = append(args, "--user="+dockerConfig.User)
+	}
+
+	if dockerConfig.WorkingDir != "" {
+		args = append(args, "--workdir="+dockerConfig.WorkingDir)
+	}
+
+	if dockerConfig.ReadOnly {
+		// Podman mounts writable /tmp, /var/tmp and /run on read-only containers unless asked not to.
+		args = append(args, "--read-only", "--read-only-tmpfs=false")
+	}
+
+	for key, value := range dockerConfig.Env {
+		args = append(args, "--env", key+"="+value)
+	}
+
+	for path, opts := range dockerConfig.TmpFS {
+		if opts != "" {
+			args = append(args, "--tmpfs", path+":"+opts)
+		} else {
+			args = append(args, "--tmpfs", path)
+		}
+	}
+
+	if len(unsupported) > 0 {
+		return nil, fmt.Errorf("%w: %s", errUnsupportedOption, strings.Join(unsupported, "; "))
+	}
+
+	return args, nil
+}
+
+// unsupportedController returns why the cgroup controller can't be used, or empty string.
+// Rootless Podman can only set limits on cgroup v2 with the controller delegated to the user.
+func (i *podmanInfo) unsupportedController(controller string) string {
+	if !i.Host.Security.Rootless {
+		return ""
+	}
+
+	if i.Host.CgroupVersion != "v2" {
+		return "rootless podman requires cgroup v2 to set resource limits, host has cgroup " + i.Host.CgroupVersion
+	}
+
+	if !slices.Contains(i.Host.CgroupControllers, controller) {
+		return "cgroup controller " + controller + " is not delegated to the rootless user"
+	}
+
+	return ""
+}
@@ -0,0 +1,83 @@
+package registry
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/easyp-tech/service/internal/core"
+)
+
+// GroupPolicy represents security defaults and requirements of a plugin group.
+// Defaults are applied to the docker section of every plugin of the group
+// when the plugin doesn't set the option itself.
+type GroupPolicy struct {
+	Runtime         string
+	SeccompProfile  string
+	AppArmorProfile string
+	CapDrop         []string
+	NoNewPrivileges bool
+	// Runtimes lists the allowed OCI runtimes, any runtime is allowed when empty.
+	// The default runtime of the engine is denoted by an empty string.
+	Runtimes []string
+	// Executors lists the allowed executors, any executor is allowed when empty.
+	Executors []string
+	// PublicKeys are paths to PEM encoded keys, images of the group must be signed
+	// by one of them when set.
+	PublicKeys []string
+}
+
+// applyGroupPolicy merges group defaults into the plugin config and rejects plugins
+// which don't satisfy the group requirements.
+func (r *Registry) applyGroupPolicy(p *plugin) error {
+	policy, ok := r.groups[p.GroupName]
+	if !ok {
+		return nil
+	}
+
+	executor := p.pluginConfig.Executor
+	if executor == "" {
+		executor = ExecutorDocker
+	}
+
+	if len(policy.Executors) > 0 && !slices.Contains(policy.Executors, executor) {
+		return fmt.Errorf("%w: group %s doesn't allow executor %q, allowed: %s",
+			core.ErrInvalidPluginConfig, p.GroupName, executor, strings.Join(policy.Executors, ", "))
+	}
+
+	dockerConfig := DockerConfig{}
+	if p.pluginConfig.Docker != nil {
+		dockerConfig = *p.pluginConfig.Docker
+	}
+
+	if dockerConfig.Runtime == "" {
+		dockerConfig.Runtime = policy.Runtime
+	}
+
+	if dockerConfig.SeccompProfile == "" {
+		dockerConfig.SeccompProfile = policy.SeccompProfile
+	}
+
+	if dockerConfig.AppArmorProfile == "" {
+		dockerConfig.AppArmorProfile = policy.AppArmorProfile
+	}
+
+	dockerConfig.NoNewPrivileges = dockerConfig.NoNewPrivileges || policy.NoNewPrivileges
+
+	capDrop := slices.Clone(dockerConfig.CapDrop)
+	for _, capability := range policy.CapDrop {
+		if !slices.Contains(capDrop, capability) {
+			capDrop = append(capDrop, capability)
+		}
+	}
+	dockerConfig.CapDrop = capDrop
+
+	if len(policy.Runtimes) > 0 && !slices.Contains(policy.Runtimes, dockerConfig.Runtime) {
+		return fmt.Errorf("%w: group %s requires runtime %s, plugin uses %q",
+			core.ErrInvalidPluginConfig, p.GroupName, strings.Join(policy.Runtimes, " or "), dockerConfig.Runtime)
+	}
+
+	p.pluginConfig.Docker = &dockerConfig
+
+	return nil
+}
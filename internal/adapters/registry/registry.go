@@ -4,12 +4,15 @@ package registry
 import (
 	"bytes"
 	"context"
+	"crypto"
 	"database/sql"
 	"encoding/json"
 	"errors"
 	"fmt"
-	"net/url"
-	"os/exec"
+	"log/slog"
+	"os"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/gofrs/uuid/v5"
@@ -18,6 +21,7 @@ import (
 	"github.com/sipki-tech/dev-platform/database"
 	"github.com/sipki-tech/dev-platform/database/connectors"
 	"github.com/sipki-tech/dev-platform/database/migrations"
+	"github.com/sipki-tech/dev-platform/logger"
 	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/types/pluginpb"
 
@@ -27,6 +31,30 @@ import (
 var _ core.Registry = &Registry{}
 var _ core.Plugin = &plugin{}
 
+// Executors.
+const (
+	ExecutorDocker     = "docker"
+	ExecutorLocal      = "local"
+	ExecutorWasm       = "wasm"
+	ExecutorKubernetes = "kubernetes"
+)
+
+const registryTimeout = time.Minute
+
+// pluginColumns are the columns of plugin rows.
+const pluginColumns = "id, group_name, name, version, config, created_at, image, registry, manifest"
+
+// Container engines.
+const (
+	EngineDocker = "docker"
+	EnginePodman = "podman"
+)
+
+var (
+	errUnknownExecutor = errors.New("unknown executor")
+	errUnknownEngine   = errors.New("unknown container engine")
+)
+
 type (
 	// DockerConfig represents Docker execution configuration
 	DockerConfig struct {
@@ -38,11 +66,81 @@ type (
 		WorkingDir string            `json:"working_dir,omitempty"`
 		ReadOnly   bool              `json:"read_only,omitempty"`
 		TmpFS      map[string]string `json:"tmpfs,omitempty"`
+		// Runtime is the OCI runtime of the container, e.g. "runsc" for gVisor.
+		Runtime string `json:"runtime,omitempty"`
+		// SeccompProfile is the name of a profile from the seccomp profiles directory.
+		SeccompProfile string `json:"seccomp_profile,omitempty"`
+		// AppArmorProfile is the name of an AppArmor profile loaded on the host.
+		AppArmorProfile string   `json:"apparmor_profile,omitempty"`
+		CapDrop         []string `json:"cap_drop,omitempty"`
+		NoNewPrivileges bool     `json:"no_new_privileges,omitempty"`
+		PidsLimit       int64    `json:"pids_limit,omitempty"`
+	}
+
+	// LocalConfig represents local binary execution configuration
+	LocalConfig struct {
+		// Binary is the file name of the plugin inside its
+		// <plugins_dir>/<group>/<name>/<version>/ directory, e.g. "protoc-gen-go".
+		Binary    string            `json:"binary,omitempty"`
+		Memory    string            `json:"memory,omitempty"`
+		CPUTime   string            `json:"cpu_time,omitempty"`
+		FileSize  string            `json:"file_size,omitempty"`
+		OpenFiles uint64            `json:"open_files,omitempty"`
+		Env       map[string]string `json:"env,omitempty"`
+	}
+
+	// WasmConfig represents WebAssembly execution configuration
+	WasmConfig struct {
+		// Module is the file name of the module inside its
+		// <modules_dir>/<group>/<name>/<version>/ directory.
+		// The module is pulled from the registry image when empty.
+		Module string `json:"module,omitempty"`
+		Memory string `json:"memory,omitempty"`
+		// CPUs is the share of a core like in DockerConfig, the module may use cpus * timeout of CPU time.
+		CPUs    string            `json:"cpus,omitempty"`
+		Timeout string            `json:"timeout,omitempty"`
+		Env     map[string]string `json:"env,omitempty"`
+	}
+
+	// CompatibilityConfig declares the compiler and the plugins the plugin is compatible with.
+	CompatibilityConfig struct {
+		// CompilerVersion is the range of CodeGeneratorRequest.compiler_version, e.g. ">=3.21.0".
+		CompilerVersion string `json:"compiler_version,omitempty"`
+		// Plugins are version ranges of plugins generated together, e.g. {"protobuf/go": ">=v1.36.0 <v2"}.
+		Plugins map[string]string `json:"plugins,omitempty"`
+		// Mode is "warn" (default) or "fail".
+		Mode string `json:"mode,omitempty"`
+	}
+
+	// PostProcessConfig are steps applied to the generated files, requests may override them.
+	PostProcessConfig struct {
+		// Header is prepended to every generated .go file, e.g. a license comment.
+		Header string `json:"header,omitempty"`
+		// PathPrefix is prepended to names of the generated files.
+		PathPrefix string `json:"path_prefix,omitempty"`
+		// Include and Exclude are globs of the files to keep and to drop, e.g. "**/*_grpc.pb.go".
+		Include  []string `json:"include,omitempty"`
+		Exclude  []string `json:"exclude,omitempty"`
+		GoFormat bool     `json:"go_format,omitempty"`
 	}
 
 	// PluginConfig represents the complete plugin configuration
 	PluginConfig struct {
-		Docker *DockerConfig `json:"docker,omitempty"`
+		// Executor selects how the plugin is run: "docker" (default), "local", "wasm" or "kubernetes".
+		Executor string        `json:"executor,omitempty"`
+		Docker   *DockerConfig `json:"docker,omitempty"`
+		Local    *LocalConfig  `json:"local,omitempty"`
+		Wasm     *WasmConfig   `json:"wasm,omitempty"`
+		// Secrets maps environment variable names to names of stored secrets.
+		Secrets map[string]string `json:"secrets,omitempty"`
+		// SecurityOverride exempts the plugin from parts of the security baseline.
+		SecurityOverride *SecurityOverride `json:"security_override,omitempty"`
+		// Parameters are default and locked options of CodeGeneratorRequest.parameter.
+		Parameters *ParametersConfig `json:"parameters,omitempty"`
+		// Compatibility are constraints checked on every generation.
+		Compatibility *CompatibilityConfig `json:"compatibility,omitempty"`
+		// PostProcess are steps applied to the generated files.
+		PostProcess *PostProcessConfig `json:"post_process,omitempty"`
 		// Future extensions can be added here:
 		// Security SecurityConfig `json:"security,omitempty"`
 		// Monitoring MonitoringConfig `json:"monitoring,omitempty"`
@@ -54,13 +152,75 @@ type (
 		Postgres   connectors.Raw
 		MigrateDir string
 		Driver     string
-		Domain     string
+		// Domain is the default registry of plugin images.
+		Domain string
+		// Registries are named upstream registries which plugins may pull their images from.
+		Registries map[string]Upstream
+		// ContainerEngine runs the docker executor: "docker" (default) or "podman".
+		ContainerEngine string
+		// ContainerBinary is the path to the engine binary, the engine name is used when empty.
+		ContainerBinary string
+		// LocalPluginsDir enables the local executor when set.
+		LocalPluginsDir string
+		// WasmModulesDir is the local store of wasm modules.
+		WasmModulesDir string
+		// KubernetesNamespace enables the kubernetes executor when set.
+		KubernetesNamespace string
+		// KubeConfig is the path to kubeconfig, in-cluster config is used when empty.
+		KubeConfig string
+		// SeccompProfilesDir contains seccomp profiles as <name>.json.
+		SeccompProfilesDir string
+		// Groups are security policies of plugin groups.
+		Groups map[string]GroupPolicy
+		// Baseline is the security profile enforced on every plugin container.
+		Baseline Baseline
+		// Limits are the ceilings of plugin configs.
+		Limits Limits
+		// Images configures pre-pulling of plugin images.
+		Images ImagesConfig
+		// CatalogInterval is the interval of catalog syncs, the background sync is disabled when zero.
+		CatalogInterval time.Duration
+		// SecretsKey is the base64 encoded AES-256 key of stored secrets.
+		// Secrets are disabled when empty.
+		SecretsKey string
+		// ModulesDir has read-only dependency modules as <name>/<version>.binpb descriptor sets.
+		ModulesDir string
+		// ConformanceDir has fixtures of plugins as <group>/<name>/<version>/<case>/request.json
+		// and response.json.
+		ConformanceDir string
 	}
 
 	// Registry is a registry for EasyP plugin server.
 	Registry struct {
-		sql    *database.SQL
-		domain *url.URL
+		sql       *database.SQL
+		executors map[string]executor
+		groups    map[string]GroupPolicy
+		baseline  Baseline
+		validator *validator
+		secretBox *secretBox
+		// upstreams are registries by name, the default one has an empty name.
+		upstreams map[string]*upstream
+		// authDir contains credentials of the upstreams for container engines.
+		authDir string
+		// keys are public keys of image signatures by group.
+		keys map[string][]crypto.PublicKey
+		// verified caches verified images as group@digest.
+		verified sync.Map
+		images   *imageManager
+		// catalogInterval is the interval of RunCatalogSync.
+		catalogInterval time.Duration
+		// modulesDir has dependency modules in addition to the stored ones.
+		modulesDir string
+		// modules caches files of modules by name@version, versions are immutable.
+		modules sync.Map
+		// conformanceDir has fixtures of plugins, none when empty.
+		conformanceDir string
+	}
+
+	// executor runs a plugin process which reads CodeGeneratorRequest from stdin
+	// and writes CodeGeneratorResponse to stdout.
+	executor interface {
+		execute(ctx context.Context, p *plugin, input []byte) ([]byte, error)
 	}
 
 	// plugin is a plugin in the registry.
@@ -71,9 +231,20 @@ type (
 		Version   string          `db:"version"`
 		Config    json.RawMessage `db:"config"`
 		CreatedAt time.Time       `db:"created_at"`
-
-		domain       *url.URL     `db:"-"`
+		// Image is an explicit image reference, see Registry.reference.
+		Image string `db:"image"`
+		// RegistryName is the name of the upstream registry, empty for the default one.
+		RegistryName string `db:"registry"`
+		// Manifest is the metadata read from the image labels.
+		Manifest pluginManifest `db:"manifest"`
+
+		ref          imageRef     `db:"-"`
+		executor     executor     `db:"-"`
 		pluginConfig PluginConfig `db:"-"`
+		// secrets are resolved values of pluginConfig.Secrets by environment variable names.
+		secrets map[string]string `db:"-"`
+		// digest is the verified image digest, the image is run by tag when empty.
+		digest string `db:"-"`
 	}
 )
 
@@ -85,6 +256,9 @@ func New(ctx context.Context, reg *prometheus.Registry, namespace string, cfg Co
 	returnErrs := []error{ // List of core.Err… returned by Repo methods.
 		core.ErrNotFound,
 		core.ErrInvalidPluginName,
+		core.ErrInvalidPluginConfig,
+		core.ErrAlreadyExists,
+		core.ErrInvalidArgument,
 	}
 
 	migrates, err := migrations.Parse(cfg.MigrateDir)
@@ -105,142 +279,416 @@ func New(ctx context.Context, reg *prometheus.Registry, namespace string, cfg Co
 		return nil, fmt.Errorf("database.NewSQL: %w", err)
 	}
 
-	u, err := url.Parse(cfg.Domain)
+	upstreams, err := newUpstreams(cfg.Domain, cfg.Registries)
+	if err != nil {
+		return nil, fmt.Errorf("newUpstreams: %w", err)
+	}
+
+	err = checkEngineUpstreams(cfg.ContainerEngine, upstreams)
+	if err != nil {
+		return nil, fmt.Errorf("checkEngineUpstreams: %w", err)
+	}
+
+	v, err := newValidator(cfg.Limits)
 	if err != nil {
-		return nil, fmt.Errorf("url.Parse: %w", err)
+		return nil, fmt.Errorf("newValidator: %w", err)
+	}
+
+	box, err := newSecretBox(cfg.SecretsKey)
+	if err != nil {
+		return nil, fmt.Errorf("newSecretBox: %w", err)
+	}
+
+	keys := make(map[string][]crypto.PublicKey, len(cfg.Groups))
+	for group, policy := range cfg.Groups {
+		keys[group], err = loadPublicKeys(policy.PublicKeys)
+		if err != nil {
+			return nil, fmt.Errorf("loadPublicKeys: %w", err)
+		}
+	}
+
+	executors := map[string]executor{
+		ExecutorWasm: newWasmExecutor(cfg.WasmModulesDir),
+	}
+
+	if cfg.LocalPluginsDir != "" {
+		executors[ExecutorLocal] = &localExecutor{dir: cfg.LocalPluginsDir}
+	}
+
+	if cfg.KubernetesNamespace != "" {
+		restConfig, err := kubernetesConfig(cfg.KubeConfig)
+		if err != nil {
+			return nil, fmt.Errorf("kubernetesConfig: %w", err)
+		}
+
+		executors[ExecutorKubernetes], err = newKubernetesExecutor(restConfig, cfg.KubernetesNamespace)
+		if err != nil {
+			return nil, fmt.Errorf("newKubernetesExecutor: %w", err)
+		}
+	}
+
+	// The credentials are written last, nothing fails after it but the container executor.
+	authDir, err := writeAuthConfig(upstreams)
+	if err != nil {
+		return nil, fmt.Errorf("writeAuthConfig: %w", err)
+	}
+
+	executors[ExecutorDocker], err = containerExecutor(cfg.ContainerEngine, cfg.ContainerBinary, cfg.SeccompProfilesDir, upstreams[""].host(), engineEnv(authDir))
+	if err != nil {
+		_ = os.RemoveAll(authDir)
+		return nil, fmt.Errorf("containerExecutor: %w", err)
 	}
 
 	return &Registry{
-		sql:    conn,
-		domain: u,
+		sql:       conn,
+		executors: executors,
+		groups:    cfg.Groups,
+		baseline:  cfg.Baseline,
+		validator: v,
+		secretBox: box,
+		upstreams: upstreams,
+		authDir:   authDir,
+		keys:      keys,
+		images:    newImageManager(cfg.Images),
+
+		catalogInterval: cfg.CatalogInterval,
+		modulesDir:      cfg.ModulesDir,
+		conformanceDir:  cfg.ConformanceDir,
 	}, nil
 }
 
+// containerExecutor returns the executor of the container engine.
+// domain is the default registry whose images are collected, env is added to the engine environment.
+func containerExecutor(engine, binary, seccompDir, domain string, env []string) (executor, error) {
+	if engine == "" {
+		engine = EngineDocker
+	}
+
+	if binary == "" {
+		binary = engine
+	}
+
+	switch engine {
+	case EngineDocker:
+		return &dockerExecutor{binary: binary, seccompDir: seccompDir, domain: domain, env: env}, nil
+	case EnginePodman:
+		return &podmanExecutor{binary: binary, seccompDir: seccompDir, domain: domain, env: env}, nil
+	default:
+		return nil, fmt.Errorf("%w: %s", errUnknownEngine, engine)
+	}
+}
+
 // Get implements core.Registry.
-func (r *Registry) Get(ctx context.Context, pluginGroup, pluginName, pluginVersion string) (p core.Plugin, err error) {
-	err = r.sql.NoTx(func(d *sqlx.DB) error {
-		dbFormat := plugin{}
+func (r *Registry) Get(ctx context.Context, pluginGroup, pluginName, pluginVersion string) (core.Plugin, error) {
+	var p *plugin
+	err := r.sql.NoTx(func(d *sqlx.DB) error {
+		var err error
+		p, err = r.load(ctx, d, pluginGroup, pluginName, pluginVersion)
+		if err != nil {
+			return fmt.Errorf("r.load: %w", err)
+		}
 
-		query := "select id, group_name, name, version, config, created_at from plugins where group_name = $1 and name = $2 and version = $3"
-		args := []any{pluginGroup, pluginName, pluginVersion}
+		err = r.resolveSecrets(ctx, d, p)
+		if err != nil {
+			return fmt.Errorf("r.resolveSecrets: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("sql.NoTx: %w", err)
+	}
+
+	err = r.verifyImage(ctx, p)
+	if err != nil {
+		return nil, fmt.Errorf("r.verifyImage: %w", err)
+	}
+
+	return p, nil
+}
+
+// load reads the plugin, prepares its config and selects its executor.
+func (r *Registry) load(ctx context.Context, d *sqlx.DB, pluginGroup, pluginName, pluginVersion string) (*plugin, error) {
+	dbFormat := plugin{}
+
+	query := "select " + pluginColumns + " from plugins where group_name = $1 and name = $2 and version = $3"
+	args := []any{pluginGroup, pluginName, pluginVersion}
+
+	if pluginVersion == "latest" {
+		query = "select " + pluginColumns + " from plugins where group_name = $1 and name = $2 order by version desc limit 1"
+		args = []any{pluginGroup, pluginName}
+	}
+
+	err := d.GetContext(ctx, &dbFormat, query, args...)
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+		return nil, fmt.Errorf("d.GetContext: %w", core.ErrNotFound)
+	case err != nil:
+		return nil, fmt.Errorf("d.GetContext: %w", err)
+	}
 
-		if pluginVersion == "latest" {
-			query = "select id, group_name, name, version, config, created_at from plugins where group_name = $1 and name = $2 order by version desc limit 1"
-			args = []any{pluginGroup, pluginName}
+	// Parse plugin configuration, rows written before validation are decoded as strictly as new ones.
+	if len(dbFormat.Config) > 0 {
+		err = parseConfig(dbFormat.Config, &dbFormat.pluginConfig)
+		if err != nil {
+			return nil, fmt.Errorf("parseConfig: %w", err)
 		}
+	}
+
+	err = r.prepare(&dbFormat)
+	if err != nil {
+		return nil, fmt.Errorf("r.prepare: %w", err)
+	}
+
+	err = r.reference(&dbFormat)
+	if err != nil {
+		return nil, fmt.Errorf("r.reference: %w", err)
+	}
+
+	kind := dbFormat.pluginConfig.Executor
+	if kind == "" {
+		kind = ExecutorDocker
+	}
+
+	e, ok := r.executors[kind]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", errUnknownExecutor, kind)
+	}
+
+	dbFormat.executor = e
+
+	return &dbFormat, nil
+}
+
+// Register implements core.Registry.
+func (r *Registry) Register(ctx context.Context, req core.RegisterPluginRequest) (*core.PluginInfo, error) {
+	config := req.Config
+	if len(bytes.TrimSpace(config)) == 0 {
+		config = []byte("{}")
+	}
+
+	p := plugin{
+		GroupName:    req.Group,
+		Name:         req.Name,
+		Version:      req.Version,
+		Config:       config,
+		Image:        req.Image,
+		RegistryName: req.Registry,
+	}
+
+	err := parseConfig(config, &p.pluginConfig)
+	if err != nil {
+		return nil, fmt.Errorf("parseConfig: %w", err)
+	}
+
+	err = r.reference(&p)
+	if err != nil {
+		return nil, fmt.Errorf("r.reference: %w", err)
+	}
 
-		err := d.GetContext(ctx, &dbFormat, query, args...)
+	err = r.readManifest(ctx, &p, len(bytes.TrimSpace(req.Config)) == 0)
+	if err != nil {
+		return nil, fmt.Errorf("r.readManifest: %w", err)
+	}
+
+	err = r.prepare(&p)
+	if err != nil {
+		return nil, fmt.Errorf("r.prepare: %w", err)
+	}
+
+	err = r.sql.Tx(ctx, nil, func(tx *sqlx.Tx) error {
+		query := `insert into plugins (group_name, name, version, config, image, registry, manifest) values ($1, $2, $3, $4, $5, $6, $7)
+			on conflict (group_name, name, version) do nothing returning id, created_at`
+
+		err := tx.GetContext(ctx, &p, query, p.GroupName, p.Name, p.Version, p.Config, p.Image, p.RegistryName, p.Manifest)
 		switch {
 		case errors.Is(err, sql.ErrNoRows):
-			return fmt.Errorf("d.GetContext: %w", core.ErrNotFound)
+			return fmt.Errorf("tx.GetContext: %w", core.ErrAlreadyExists)
 		case err != nil:
-			return fmt.Errorf("d.GetContext: %w", err)
+			return fmt.Errorf("tx.GetContext: %w", err)
 		}
 
-		// Parse plugin configuration
-		if len(dbFormat.Config) > 0 {
-			if err := json.Unmarshal(dbFormat.Config, &dbFormat.pluginConfig); err != nil {
-				return fmt.Errorf("json.Unmarshal config: %w", err)
-			}
+		err = auditOverride(ctx, tx, &p)
+		if err != nil {
+			return fmt.Errorf("auditOverride: %w", err)
 		}
 
-		dbFormat.domain = r.domain
-		p = &dbFormat
 		return nil
 	})
 	if err != nil {
-		return nil, fmt.Errorf("sql.NoTx: %w", err)
+		return nil, fmt.Errorf("sql.Tx: %w", err)
 	}
 
-	return p, nil
+	info := p.Info(ctx)
+	r.images.enqueue(*info)
+
+	return r.withImageStatus(*info), nil
 }
 
-// Close database connection.
-func (r *Registry) Close() error {
-	return r.sql.Close()
+// readManifest reads the manifest from the labels of the plugin image, seed replaces the config
+// with the config label. Plugins without an image and unreachable images get an empty manifest.
+func (r *Registry) readManifest(ctx context.Context, p *plugin, seed bool) error {
+	if !usesImage(p) {
+		return nil
+	}
+
+	labels, err := p.ref.upstream.oci.labels(ctx, p.ref.repository, p.ref.reference)
+	if err != nil {
+		logger.FromContext(ctx).Warn("read plugin manifest",
+			slog.String("image", p.image()),
+			slog.String(logger.Error.String(), err.Error()),
+		)
+
+		return nil
+	}
+
+	p.Manifest, err = parseManifest(labels)
+	if err != nil {
+		return fmt.Errorf("parseManifest: %w", err)
+	}
+
+	if config := labels[labelPluginConfig]; seed && config != "" {
+		p.Config = json.RawMessage(config)
+		p.pluginConfig = PluginConfig{}
+
+		err = parseConfig(p.Config, &p.pluginConfig)
+		if err != nil {
+			return fmt.Errorf("parseConfig: %w", err)
+		}
+	}
+
+	return nil
 }
 
-// Health checks the health of the registry.
-func (r *Registry) Health(ctx context.Context) error {
-	return r.sql.NoTx(func(db *sqlx.DB) error { return db.PingContext(ctx) })
+// parseConfig strictly decodes the plugin config, unknown fields are rejected.
+func parseConfig(config []byte, cfg *PluginConfig) error {
+	decoder := json.NewDecoder(bytes.NewReader(config))
+	decoder.DisallowUnknownFields()
+
+	err := decoder.Decode(cfg)
+	if err != nil {
+		return fmt.Errorf("%w: %s", core.ErrInvalidPluginConfig, err)
+	}
+
+	return nil
 }
 
-// Generate implements core.Plugin.
-func (p *plugin) Generate(ctx context.Context, req *pluginpb.CodeGeneratorRequest) (*pluginpb.CodeGeneratorResponse, error) {
-	requestData, err := proto.Marshal(req)
+// List implements core.Registry.
+func (r *Registry) List(ctx context.Context) (plugins []core.PluginInfo, err error) {
+	err = r.sql.NoTx(func(d *sqlx.DB) error {
+		var rows []plugin
+		query := "select " + pluginColumns + " from plugins order by group_name, name, version"
+
+		err := d.SelectContext(ctx, &rows, query)
+		if err != nil {
+			return fmt.Errorf("d.SelectContext: %w", err)
+		}
+
+		checks, err := determinismChecks(ctx, d)
+		if err != nil {
+			return fmt.Errorf("determinismChecks: %w", err)
+		}
+
+		plugins = make([]core.PluginInfo, len(rows))
+		for i := range rows {
+			// Rows of removed registries are still listed with their stored image, marked as failed.
+			refErr := r.reference(&rows[i])
+			plugins[i] = *r.withImageStatus(*rows[i].Info(ctx))
+			plugins[i].Determinism = checks[plugins[i].ID]
+			if refErr != nil {
+				plugins[i].ImageStatus, plugins[i].ImageError = ImageFailed, refErr.Error()
+			}
+		}
+
+		return nil
+	})
 	if err != nil {
-		return nil, fmt.Errorf("proto.Marshal: %w", err)
+		return nil, fmt.Errorf("sql.NoTx: %w", err)
 	}
 
-	imageName := p.domain.String() + "/" + p.GroupName + "/" + p.Name + ":" + p.Version
+	return plugins, nil
+}
 
-	// Build Docker command with configuration from database
-	args := []string{"run", "--rm", "-i"}
+// withImageStatus adds the pull state of the plugin image.
+func (r *Registry) withImageStatus(info core.PluginInfo) *core.PluginInfo {
+	status, ok := r.images.status(info)
+	if ok {
+		info.ImageStatus = status.state
+		info.ImageError = status.err
+	}
 
-	// Get Docker configuration
-	dockerConfig := p.pluginConfig.Docker
+	return &info
+}
 
-	// Apply Docker configuration from database
-	if dockerConfig.Network != "" {
-		args = append(args, "--network="+dockerConfig.Network)
-	} else {
-		// Default security: no network access
-		args = append(args, "--network=none")
+// prepare validates the plugin config and applies the group policy and the security baseline.
+func (r *Registry) prepare(p *plugin) error {
+	err := r.validator.validate(&p.pluginConfig, r.executors)
+	if err != nil {
+		return fmt.Errorf("r.validator.validate: %w", err)
 	}
 
-	if dockerConfig.Memory != "" {
-		args = append(args, "--memory="+dockerConfig.Memory)
-	} else {
-		// Default memory limit
-		args = append(args, "--memory=128m")
+	if len(p.pluginConfig.Secrets) > 0 && r.secretBox == nil {
+		return fmt.Errorf("%w: %w", core.ErrInvalidPluginConfig, core.ErrSecretsDisabled)
 	}
 
-	if dockerConfig.CPUs != "" {
-		args = append(args, "--cpus="+dockerConfig.CPUs)
-	} else {
-		// Default CPU limit
-		args = append(args, "--cpus=1.0")
+	if p.pluginConfig.Compatibility != nil {
+		err := p.pluginConfig.Compatibility.info().Check()
+		if err != nil {
+			return fmt.Errorf("%w: compatibility: %w", core.ErrInvalidPluginConfig, err)
+		}
 	}
 
-	if dockerConfig.User != "" {
-		args = append(args, "--user="+dockerConfig.User)
+	if p.pluginConfig.PostProcess != nil {
+		err := p.pluginConfig.PostProcess.info().Check()
+		if err != nil {
+			return fmt.Errorf("%w: post_process: %w", core.ErrInvalidPluginConfig, err)
+		}
 	}
 
-	if dockerConfig.WorkingDir != "" {
-		args = append(args, "--workdir="+dockerConfig.WorkingDir)
+	if p.pluginConfig.Parameters != nil {
+		problems := p.pluginConfig.Parameters.check(&p.Manifest)
+		if len(problems) > 0 {
+			return fmt.Errorf("%w: %s", core.ErrInvalidPluginConfig, strings.Join(problems, "; "))
+		}
 	}
 
-	if dockerConfig.ReadOnly {
-		args = append(args, "--read-only")
+	err = r.applyGroupPolicy(p)
+	if err != nil {
+		return fmt.Errorf("r.applyGroupPolicy: %w", err)
 	}
 
-	// Add environment variables
-	for key, value := range dockerConfig.Env {
-		args = append(args, "--env", key+"="+value)
+	err = r.applyBaseline(p)
+	if err != nil {
+		return fmt.Errorf("r.applyBaseline: %w", err)
 	}
 
-	// Add tmpfs mounts
-	for path, opts := range dockerConfig.TmpFS {
-		if opts != "" {
-			args = append(args, "--tmpfs", path+":"+opts)
-		} else {
-			args = append(args, "--tmpfs", path)
-		}
+	return nil
+}
+
+// Close database connection and removes credentials of container engines.
+func (r *Registry) Close() error {
+	if r.authDir != "" {
+		_ = os.RemoveAll(r.authDir)
 	}
 
-	args = append(args, imageName)
+	return r.sql.Close()
+}
 
-	cmd := exec.CommandContext(ctx, "docker", args...)
-	cmd.Stdin = bytes.NewReader(requestData)
+// Health checks the health of the registry.
+func (r *Registry) Health(ctx context.Context) error {
+	return r.sql.NoTx(func(db *sqlx.DB) error { return db.PingContext(ctx) })
+}
 
-	output, err := cmd.Output()
+// Generate implements core.Plugin.
+func (p *plugin) Generate(ctx context.Context, req *pluginpb.CodeGeneratorRequest) (*pluginpb.CodeGeneratorResponse, error) {
+	requestData, err := proto.Marshal(req)
 	if err != nil {
-		var exitErr *exec.ExitError
-		if errors.As(err, &exitErr) {
-			return nil, fmt.Errorf("plugin execution failed: %s, stderr: %s", err, string(exitErr.Stderr))
-		}
+		return nil, fmt.Errorf("proto.Marshal: %w", err)
+	}
 
-		return nil, fmt.Errorf("cmd.Output: %w", err)
+	output, err := p.executor.execute(ctx, p, requestData)
+	if err != nil {
+		return nil, fmt.Errorf("p.executor.execute: %w", p.redactError(err))
 	}
 
 	var response pluginpb.CodeGeneratorResponse
@@ -248,16 +696,73 @@ func (p *plugin) Generate(ctx context.Context, req *pluginpb.CodeGeneratorReques
 		return nil, fmt.Errorf("proto.Unmarshal: %w", err)
 	}
 
+	if response.Error != nil {
+		response.Error = proto.String(p.redact(response.GetError()))
+	}
+
 	return &response, nil
 }
 
+// repository returns the repository of the plugin image, e.g. protobuf/go.
+func (p *plugin) repository() string {
+	return p.GroupName + "/" + p.Name
+}
+
+// image returns the image reference of the plugin, by digest if it is verified.
+// The stored image is returned when its registry is not configured anymore.
+func (p *plugin) image() string {
+	if p.ref.upstream == nil {
+		return p.Image
+	}
+
+	ref := p.ref
+	if p.digest != "" {
+		ref.reference = p.digest
+	}
+
+	return ref.String()
+}
+
 // Info implements core.Plugin.
 func (p *plugin) Info(_ context.Context) *core.PluginInfo {
 	return &core.PluginInfo{
-		ID:        p.ID,
-		Group:     p.GroupName,
-		Name:      p.Name,
-		Version:   p.Version,
-		CreatedAt: p.CreatedAt,
+		ID:            p.ID,
+		Group:         p.GroupName,
+		Name:          p.Name,
+		Version:       p.Version,
+		CreatedAt:     p.CreatedAt,
+		Image:         p.image(),
+		Registry:      p.RegistryName,
+		Manifest:      p.Manifest.info(),
+		Compatibility: p.pluginConfig.Compatibility.info(),
+		PostProcess:   p.pluginConfig.PostProcess.info(),
+	}
+}
+
+// info converts the steps to the core type.
+func (c *PostProcessConfig) info() core.PostProcess {
+	if c == nil {
+		return core.PostProcess{}
+	}
+
+	return core.PostProcess{
+		Header:     &c.Header,
+		PathPrefix: &c.PathPrefix,
+		Include:    c.Include,
+		Exclude:    c.Exclude,
+		GoFormat:   &c.GoFormat,
+	}
+}
+
+// info converts the constraints to the core type.
+func (c *CompatibilityConfig) info() core.Compatibility {
+	if c == nil {
+		return core.Compatibility{}
+	}
+
+	return core.Compatibility{
+		CompilerVersion: c.CompilerVersion,
+		Plugins:         c.Plugins,
+		Mode:            c.Mode,
 	}
 }
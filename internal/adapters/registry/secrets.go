@@ -0,0 +1,252 @@
+package registry
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+
+	"github.com/easyp-tech/service/internal/core"
+)
+
+var _ core.SecretStore = &Registry{}
+
+const (
+	secretKeySize = 32 // AES-256.
+	redacted      = "[REDACTED]"
+)
+
+var errInvalidSecretKey = errors.New("secret key must be 32 bytes encoded in base64")
+
+type (
+	// secretBox encrypts secrets with AES-256-GCM, the secret name is authenticated
+	// so a ciphertext can't be moved to another name.
+	secretBox struct {
+		aead cipher.AEAD
+	}
+
+	// secret is a stored encrypted secret.
+	secret struct {
+		Name       string    `db:"name"`
+		Nonce      []byte    `db:"nonce"`
+		Ciphertext []byte    `db:"ciphertext"`
+		CreatedAt  time.Time `db:"created_at"`
+		UpdatedAt  time.Time `db:"updated_at"`
+	}
+
+	// redactedError hides secret values in the message of the wrapped error.
+	redactedError struct {
+		err error
+		msg string
+	}
+)
+
+// newSecretBox returns nil when key is empty, which disables secrets.
+func newSecretBox(key string) (*secretBox, error) {
+	if key == "" {
+		return nil, nil //nolint:nilnil // Secrets are optional.
+	}
+
+	raw, err := base64.StdEncoding.DecodeString(key)
+	if err != nil || len(raw) != secretKeySize {
+		return nil, errInvalidSecretKey
+	}
+
+	block, err := aes.NewCipher(raw)
+	if err != nil {
+		return nil, fmt.Errorf("aes.NewCipher: %w", err)
+	}
+
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, fmt.Errorf("cipher.NewGCM: %w", err)
+	}
+
+	return &secretBox{aead: aead}, nil
+}
+
+func (b *secretBox) seal(name, value string) (nonce, ciphertext []byte, err error) {
+	nonce = make([]byte, b.aead.NonceSize())
+	_, err = rand.Read(nonce)
+	if err != nil {
+		return nil, nil, fmt.Errorf("rand.Read: %w", err)
+	}
+
+	return nonce, b.aead.Seal(nil, nonce, []byte(value), []byte(name)), nil
+}
+
+func (b *secretBox) open(s *secret) (string, error) {
+	value, err := b.aead.Open(nil, s.Nonce, s.Ciphertext, []byte(s.Name))
+	if err != nil {
+		return "", fmt.Errorf("aead.Open %s: %w", s.Name, err)
+	}
+
+	return string(value), nil
+}
+
+// PutSecret implements core.SecretStore.
+func (r *Registry) PutSecret(ctx context.Context, name, value string) (*core.SecretInfo, error) {
+	if r.secretBox == nil {
+		return nil, core.ErrSecretsDisabled
+	}
+
+	nonce, ciphertext, err := r.secretBox.seal(name, value)
+	if err != nil {
+		return nil, fmt.Errorf("r.secretBox.seal: %w", err)
+	}
+
+	row := secret{Name: name, Nonce: nonce, Ciphertext: ciphertext}
+	err = r.sql.NoTx(func(d *sqlx.DB) error {
+		query := `insert into secrets (name, nonce, ciphertext) values ($1, $2, $3)
+			on conflict (name) do update set nonce = excluded.nonce, ciphertext = excluded.ciphertext, updated_at = now()
+			returning created_at, updated_at`
+
+		err := d.GetContext(ctx, &row, query, row.Name, row.Nonce, row.Ciphertext)
+		if err != nil {
+			return fmt.Errorf("d.GetContext: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("sql.NoTx: %w", err)
+	}
+
+	return row.info(), nil
+}
+
+// DeleteSecret implements core.SecretStore.
+func (r *Registry) DeleteSecret(ctx context.Context, name string) error {
+	return r.sql.NoTx(func(d *sqlx.DB) error {
+		res, err := d.ExecContext(ctx, "delete from secrets where name = $1", name)
+		if err != nil {
+			return fmt.Errorf("d.ExecContext: %w", err)
+		}
+
+		n, err := res.RowsAffected()
+		if err != nil {
+			return fmt.Errorf("res.RowsAffected: %w", err)
+		}
+
+		if n == 0 {
+			return fmt.Errorf("secret %s: %w", name, core.ErrNotFound)
+		}
+
+		return nil
+	})
+}
+
+// Secrets implements core.SecretStore.
+func (r *Registry) Secrets(ctx context.Context) (secrets []core.SecretInfo, err error) {
+	err = r.sql.NoTx(func(d *sqlx.DB) error {
+		var rows []secret
+		err := d.SelectContext(ctx, &rows, "select name, created_at, updated_at from secrets order by name")
+		if err != nil {
+			return fmt.Errorf("d.SelectContext: %w", err)
+		}
+
+		secrets = make([]core.SecretInfo, len(rows))
+		for i := range rows {
+			secrets[i] = *rows[i].info()
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("sql.NoTx: %w", err)
+	}
+
+	return secrets, nil
+}
+
+// resolveSecrets decrypts the secrets referenced by the plugin config.
+func (r *Registry) resolveSecrets(ctx context.Context, d sqlx.QueryerContext, p *plugin) error {
+	refs := p.pluginConfig.Secrets
+	if len(refs) == 0 {
+		return nil
+	}
+
+	if r.secretBox == nil {
+		return fmt.Errorf("%w: %w", core.ErrInvalidPluginConfig, core.ErrSecretsDisabled)
+	}
+
+	names := slices.Compact(slices.Sorted(maps.Values(refs)))
+
+	var rows []secret
+	err := sqlx.SelectContext(ctx, d, &rows, "select name, nonce, ciphertext from secrets where name = any($1)", pq.Array(names))
+	if err != nil {
+		return fmt.Errorf("sqlx.SelectContext: %w", err)
+	}
+
+	values := make(map[string]string, len(rows))
+	for i := range rows {
+		values[rows[i].Name], err = r.secretBox.open(&rows[i])
+		if err != nil {
+			return fmt.Errorf("r.secretBox.open: %w", err)
+		}
+	}
+
+	p.secrets = make(map[string]string, len(refs))
+	for env, name := range refs {
+		value, ok := values[name]
+		if !ok {
+			return fmt.Errorf("%w: secret %s is not found", core.ErrInvalidPluginConfig, name)
+		}
+
+		p.secrets[env] = value
+	}
+
+	return nil
+}
+
+func (s *secret) info() *core.SecretInfo {
+	return &core.SecretInfo{
+		Name:      s.Name,
+		CreatedAt: s.CreatedAt,
+		UpdatedAt: s.UpdatedAt,
+	}
+}
+
+// secretEnv returns the secrets of the plugin as NAME=value pairs.
+func (p *plugin) secretEnv() []string {
+	env := make([]string, 0, len(p.secrets))
+	for name, value := range p.secrets {
+		env = append(env, name+"="+value)
+	}
+
+	return env
+}
+
+// redact replaces secret values of the plugin in s.
+func (p *plugin) redact(s string) string {
+	for _, value := range p.secrets {
+		s = strings.ReplaceAll(s, value, redacted)
+	}
+
+	return s
+}
+
+// redactError hides secret values of the plugin in the error message.
+func (p *plugin) redactError(err error) error {
+	if err == nil || len(p.secrets) == 0 {
+		return err
+	}
+
+	return &redactedError{err: err, msg: p.redact(err.Error())}
+}
+
+// Error implements error.
+func (e *redactedError) Error() string { return e.msg }
+
+// Unwrap returns the original error.
+func (e *redactedError) Unwrap() error { return e.err }
@@ -0,0 +1,267 @@
+package registry
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/jmoiron/sqlx"
+
+	"github.com/easyp-tech/service/internal/core"
+)
+
+// Cosign signature format.
+const (
+	artifactTypeCosignSignature  = "application/vnd.dev.cosign.artifact.sig.v1+json"
+	mediaTypeCosignSimpleSigning = "application/vnd.dev.cosign.simplesigning.v1+json"
+	cosignSignatureAnnotation    = "dev.cosignproject.cosign/signature"
+	cosignSignatureType          = "cosign container image signature"
+)
+
+var (
+	errSignatureMismatch = errors.New("signature doesn't match any public key of the group")
+	errSignaturePayload  = errors.New("signature payload doesn't match the image")
+	errUnsupportedKey    = errors.New("unsupported public key type")
+)
+
+type (
+	// imageSignature is a signature of the simple signing payload.
+	imageSignature struct {
+		Payload   []byte `db:"payload"`
+		Signature string `db:"signature"`
+	}
+
+	// simpleSigning is the payload signed by cosign.
+	simpleSigning struct {
+		Critical struct {
+			Identity struct {
+				DockerReference string `json:"docker-reference"`
+			} `json:"identity"`
+			Image struct {
+				DockerManifestDigest string `json:"docker-manifest-digest"`
+			} `json:"image"`
+			Type string `json:"type"`
+		} `json:"critical"`
+	}
+)
+
+// loadPublicKeys reads PEM encoded PKIX public keys: ECDSA, Ed25519 or RSA.
+func loadPublicKeys(paths []string) ([]crypto.PublicKey, error) {
+	keys := make([]crypto.PublicKey, 0, len(paths))
+	for _, path := range paths {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("os.ReadFile: %w", err)
+		}
+
+		block, _ := pem.Decode(data)
+		if block == nil {
+			return nil, fmt.Errorf("%w: %s is not PEM encoded", errUnsupportedKey, path)
+		}
+
+		key, err := x509.ParsePKIXPublicKey(block.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("x509.ParsePKIXPublicKey %s: %w", path, err)
+		}
+
+		switch key.(type) {
+		case *ecdsa.PublicKey, ed25519.PublicKey, *rsa.PublicKey:
+		default:
+			return nil, fmt.Errorf("%w: %s: %T", errUnsupportedKey, path, key)
+		}
+
+		keys = append(keys, key)
+	}
+
+	return keys, nil
+}
+
+// usesImage reports whether the plugin runs the registry image.
+func usesImage(p *plugin) bool {
+	switch p.pluginConfig.Executor {
+	case "", ExecutorDocker, ExecutorKubernetes:
+		return true
+	case ExecutorWasm:
+		return p.pluginConfig.Wasm == nil || p.pluginConfig.Wasm.Module == ""
+	default:
+		return false
+	}
+}
+
+// verifyImage resolves the image tag to a digest and checks that it is signed by a key of the group.
+// The plugin is then run by the verified digest. Successful verifications are cached per digest.
+// Images are resolved in the upstream registry of the plugin.
+func (r *Registry) verifyImage(ctx context.Context, p *plugin) error {
+	keys := r.keys[p.GroupName]
+	if len(keys) == 0 || !usesImage(p) {
+		return nil
+	}
+
+	oci := p.ref.upstream.oci
+	repository := p.ref.repository
+	digest, err := oci.resolve(ctx, repository, p.ref.reference)
+	if err != nil {
+		return fmt.Errorf("oci.resolve: %w", err)
+	}
+
+	cacheKey := p.GroupName + "@" + digest
+	if _, ok := r.verified.Load(cacheKey); ok {
+		p.digest = digest
+		return nil
+	}
+
+	signatures, err := r.imageSignatures(ctx, oci, repository, digest)
+	if err != nil {
+		return fmt.Errorf("r.imageSignatures: %w", err)
+	}
+
+	if len(signatures) == 0 {
+		return fmt.Errorf("%w: %s@%s", core.ErrUnsignedImage, repository, digest)
+	}
+
+	for _, signature := range signatures {
+		err = verifySignature(keys, signature, digest)
+		if err == nil {
+			r.verified.Store(cacheKey, struct{}{})
+			p.digest = digest
+
+			return nil
+		}
+	}
+
+	return fmt.Errorf("%w: %s@%s: %w", core.ErrInvalidSignature, repository, digest, err)
+}
+
+// imageSignatures collects signatures of the digest from the database, OCI referrers
+// and the cosign signature tag.
+func (r *Registry) imageSignatures(ctx context.Context, oci *ociClient, repository, digest string) ([]imageSignature, error) {
+	var signatures []imageSignature
+	err := r.sql.NoTx(func(d *sqlx.DB) error {
+		err := d.SelectContext(ctx, &signatures, "select payload, signature from signatures where digest = $1", digest)
+		if err != nil {
+			return fmt.Errorf("d.SelectContext: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("sql.NoTx: %w", err)
+	}
+
+	referrers, err := oci.referrers(ctx, repository, digest, artifactTypeCosignSignature)
+	if err != nil {
+		return nil, fmt.Errorf("oci.referrers: %w", err)
+	}
+
+	references := make([]string, 0, len(referrers)+1)
+	for _, referrer := range referrers {
+		references = append(references, referrer.Digest)
+	}
+	references = append(references, referrersTag(digest)+".sig")
+
+	for _, reference := range references {
+		manifest, _, err := oci.manifest(ctx, repository, reference)
+		switch {
+		case errors.Is(err, errRegistryNotFound):
+			continue
+		case err != nil:
+			return nil, fmt.Errorf("oci.manifest: %w", err)
+		}
+
+		for _, layer := range manifest.Layers {
+			signature, ok := layer.Annotations[cosignSignatureAnnotation]
+			if layer.MediaType != mediaTypeCosignSimpleSigning || !ok {
+				continue
+			}
+
+			payload, err := oci.blob(ctx, repository, layer.Digest)
+			if err != nil {
+				return nil, fmt.Errorf("oci.blob: %w", err)
+			}
+
+			signatures = append(signatures, imageSignature{Payload: payload, Signature: signature})
+		}
+	}
+
+	return signatures, nil
+}
+
+// AddSignature implements core.Registry.
+func (r *Registry) AddSignature(ctx context.Context, digest string, payload []byte, signature string) error {
+	err := checkPayload(payload, digest)
+	if err != nil {
+		return fmt.Errorf("%w: %w", core.ErrInvalidArgument, err)
+	}
+
+	_, err = base64.StdEncoding.DecodeString(signature)
+	if err != nil {
+		return fmt.Errorf("%w: signature is not base64: %w", core.ErrInvalidArgument, err)
+	}
+
+	return r.sql.NoTx(func(d *sqlx.DB) error {
+		query := "insert into signatures (digest, payload, signature) values ($1, $2, $3) on conflict do nothing"
+
+		_, err := d.ExecContext(ctx, query, digest, payload, signature)
+		if err != nil {
+			return fmt.Errorf("d.ExecContext: %w", err)
+		}
+
+		return nil
+	})
+}
+
+// verifySignature checks the signature of the payload with the keys and that the payload
+// is a cosign signature of the digest.
+func verifySignature(keys []crypto.PublicKey, signature imageSignature, digest string) error {
+	sig, err := base64.StdEncoding.DecodeString(signature.Signature)
+	if err != nil {
+		return fmt.Errorf("base64.DecodeString: %w", err)
+	}
+
+	hash := sha256.Sum256(signature.Payload)
+	verified := false
+	for _, key := range keys {
+		switch key := key.(type) {
+		case *ecdsa.PublicKey:
+			verified = ecdsa.VerifyASN1(key, hash[:], sig)
+		case ed25519.PublicKey:
+			verified = ed25519.Verify(key, signature.Payload, sig)
+		case *rsa.PublicKey:
+			verified = rsa.VerifyPKCS1v15(key, crypto.SHA256, hash[:], sig) == nil
+		}
+
+		if verified {
+			break
+		}
+	}
+
+	if !verified {
+		return errSignatureMismatch
+	}
+
+	return checkPayload(signature.Payload, digest)
+}
+
+func checkPayload(payload []byte, digest string) error {
+	var signed simpleSigning
+	err := json.Unmarshal(payload, &signed)
+	if err != nil {
+		return fmt.Errorf("json.Unmarshal: %w", err)
+	}
+
+	if signed.Critical.Type != cosignSignatureType || signed.Critical.Image.DockerManifestDigest != digest {
+		return fmt.Errorf("%w: %s", errSignaturePayload, digest)
+	}
+
+	return nil
+}
@@ -0,0 +1,327 @@
+package registry
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/easyp-tech/service/internal/core"
+)
+
+var (
+	repositoryPattern = regexp.MustCompile(`^[a-z0-9]+(?:[._-][a-z0-9]+)*(?:/[a-z0-9]+(?:[._-][a-z0-9]+)*)*$`)
+	tagPattern        = regexp.MustCompile(`^[A-Za-z0-9_][A-Za-z0-9_.-]{0,127}$`)
+	imageDigestRegexp = regexp.MustCompile(`^sha256:[a-f0-9]{64}$`)
+)
+
+var (
+	errInvalidCA       = errors.New("no certificates in CA file")
+	errInvalidUpstream = errors.New("invalid upstream registry")
+)
+
+type (
+	// Upstream is a named OCI registry which plugin images are pulled from.
+	Upstream struct {
+		// Domain of the registry, e.g. "ghcr.io" or "registry.example.com:5000".
+		Domain   string
+		Username string
+		Password string
+		// Insecure uses plain HTTP.
+		Insecure bool
+		// CAFile is a PEM bundle trusted in addition to the system roots.
+		CAFile string
+		// PullSecret is the name of the kubernetes secret used by plugin pods to pull images.
+		PullSecret string
+	}
+
+	// upstream is a configured registry with its client.
+	upstream struct {
+		// name is empty for the default registry.
+		name string
+		Upstream
+		oci *ociClient
+	}
+
+	// imageRef is the resolved image of a plugin.
+	imageRef struct {
+		upstream   *upstream
+		repository string
+		// reference is a tag or a digest.
+		reference string
+	}
+)
+
+func newUpstream(name string, cfg Upstream) (*upstream, error) {
+	transport, ok := http.DefaultTransport.(*http.Transport)
+	if !ok {
+		transport = &http.Transport{}
+	}
+	transport = transport.Clone()
+
+	if cfg.CAFile != "" {
+		pem, err := os.ReadFile(cfg.CAFile)
+		if err != nil {
+			return nil, fmt.Errorf("os.ReadFile: %w", err)
+		}
+
+		pool, err := x509.SystemCertPool()
+		if err != nil {
+			pool = x509.NewCertPool()
+		}
+
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("%w: %s", errInvalidCA, cfg.CAFile)
+		}
+
+		transport.TLSClientConfig = &tls.Config{RootCAs: pool, MinVersion: tls.VersionTLS12}
+	}
+
+	return &upstream{
+		name:     name,
+		Upstream: cfg,
+		oci: &ociClient{
+			client:   &http.Client{Timeout: registryTimeout, Transport: transport},
+			domain:   cfg.Domain,
+			username: cfg.Username,
+			password: cfg.Password,
+			insecure: cfg.Insecure,
+		},
+	}, nil
+}
+
+// host returns the domain as it is used in image references.
+func (u *upstream) host() string {
+	host := strings.TrimPrefix(strings.TrimPrefix(u.Domain, "https://"), "http://")
+
+	return strings.TrimSuffix(host, "/")
+}
+
+// newUpstreams builds the default registry and the named ones.
+// The default registry takes settings of the named registry with the same domain.
+func newUpstreams(domain string, named map[string]Upstream) (map[string]*upstream, error) {
+	upstreams := make(map[string]*upstream, len(named)+1)
+	defaultHost := (&upstream{Upstream: Upstream{Domain: domain}}).host()
+	defaultCfg := Upstream{Domain: domain}
+	// hosts are unique, so an image reference resolves to a single registry.
+	hosts := make(map[string]string, len(named))
+
+	for _, name := range slices.Sorted(maps.Keys(named)) {
+		cfg := named[name]
+		if !namePattern.MatchString(name) || cfg.Domain == "" {
+			return nil, fmt.Errorf("%w: %q must have a name matching %s and a domain", errInvalidUpstream, name, namePattern)
+		}
+
+		u, err := newUpstream(name, cfg)
+		if err != nil {
+			return nil, fmt.Errorf("newUpstream: %w", err)
+		}
+
+		if other, ok := hosts[u.host()]; ok {
+			return nil, fmt.Errorf("%w: %q and %q have the same domain %s", errInvalidUpstream, other, name, u.host())
+		}
+		hosts[u.host()] = name
+
+		if u.host() == defaultHost {
+			defaultCfg = cfg
+		}
+
+		upstreams[name] = u
+	}
+
+	var err error
+	upstreams[""], err = newUpstream("", defaultCfg)
+	if err != nil {
+		return nil, fmt.Errorf("newUpstream: %w", err)
+	}
+
+	return upstreams, nil
+}
+
+// checkEngineUpstreams rejects the registry settings which the container engine can't apply when it pulls:
+// Docker reads TLS settings from the daemon only and Podman has no flag for a CA bundle file.
+func checkEngineUpstreams(engine string, upstreams map[string]*upstream) error {
+	// The default registry has settings of the named registry with its domain only, it is checked by that name.
+	for _, name := range slices.Sorted(maps.Keys(upstreams)) {
+		u := upstreams[name]
+		if name == "" {
+			continue
+		}
+
+		var unsupported []string
+		if u.Insecure && engine != EnginePodman {
+			unsupported = append(unsupported, "insecure")
+		}
+
+		if u.CAFile != "" {
+			unsupported = append(unsupported, "ca_file")
+		}
+
+		if len(unsupported) > 0 {
+			if engine == "" {
+				engine = EngineDocker
+			}
+
+			return fmt.Errorf("%w: %q: %s can't be applied by %s, configure the engine instead", errInvalidUpstream, name, strings.Join(unsupported, ", "), engine)
+		}
+	}
+
+	return nil
+}
+
+// reference resolves the image of the plugin:
+//   - neither image nor registry: <domain>/<group>/<name>:<version> in the default registry;
+//   - registry only: <group>/<name>:<version> in the named registry;
+//   - image with registry: the image is a repository of the named registry;
+//   - image only: a full reference, its domain must be one of the configured registries.
+//
+// The plugin version is used as the tag when the image has neither a tag nor a digest.
+func (r *Registry) reference(p *plugin) error {
+	u, ok := r.upstreams[p.RegistryName]
+	if !ok {
+		return fmt.Errorf("%w: unknown registry %q", core.ErrInvalidPluginConfig, p.RegistryName)
+	}
+
+	if p.Image == "" {
+		p.ref = imageRef{upstream: u, repository: p.repository(), reference: p.Version}
+
+		return nil
+	}
+
+	domain, repository, reference, err := parseImage(p.Image)
+	if err != nil {
+		return fmt.Errorf("parseImage: %w", err)
+	}
+
+	switch {
+	case domain != "" && p.RegistryName != "":
+		return fmt.Errorf("%w: image %q of registry %q must not include a domain", core.ErrInvalidPluginConfig, p.Image, p.RegistryName)
+	case domain != "":
+		u = r.upstreamOf(domain)
+		if u == nil {
+			return fmt.Errorf("%w: registry %q of image %q is not configured", core.ErrInvalidPluginConfig, domain, p.Image)
+		}
+	}
+
+	if u.host() == dockerHubDomain && !strings.Contains(repository, "/") {
+		repository = "library/" + repository
+	}
+
+	if reference == "" {
+		reference = p.Version
+	}
+
+	p.ref = imageRef{upstream: u, repository: repository, reference: reference}
+
+	return nil
+}
+
+// upstreamOf returns the registry of the domain, named registries take precedence over the default one.
+func (r *Registry) upstreamOf(domain string) *upstream {
+	var found *upstream
+	for _, u := range r.upstreams {
+		if u.host() == domain && (found == nil || found.name == "") {
+			found = u
+		}
+	}
+
+	return found
+}
+
+// parseImage splits the image reference into its domain, repository and tag or digest.
+// The first component is the domain if it contains a dot or a port or is localhost.
+func parseImage(image string) (domain, repository, reference string, err error) {
+	rest := image
+	if name, digest, ok := strings.Cut(rest, "@"); ok {
+		if !imageDigestRegexp.MatchString(digest) {
+			return "", "", "", fmt.Errorf("%w: image %q has an invalid digest", core.ErrInvalidPluginConfig, image)
+		}
+
+		rest, reference = name, digest
+	} else if i := strings.LastIndex(rest, ":"); i > strings.LastIndex(rest, "/") {
+		rest, reference = rest[:i], rest[i+1:]
+		if !tagPattern.MatchString(reference) {
+			return "", "", "", fmt.Errorf("%w: image %q has an invalid tag", core.ErrInvalidPluginConfig, image)
+		}
+	}
+
+	if first, path, ok := strings.Cut(rest, "/"); ok && (strings.ContainsAny(first, ".:") || first == "localhost") {
+		domain, rest = first, path
+	}
+
+	if !repositoryPattern.MatchString(rest) {
+		return "", "", "", fmt.Errorf("%w: image %q has an invalid repository", core.ErrInvalidPluginConfig, image)
+	}
+
+	return domain, rest, reference, nil
+}
+
+// String returns the full image reference.
+func (ref imageRef) String() string {
+	if imageDigestRegexp.MatchString(ref.reference) {
+		return ref.upstream.host() + "/" + ref.repository + "@" + ref.reference
+	}
+
+	return ref.upstream.host() + "/" + ref.repository + ":" + ref.reference
+}
+
+// writeAuthConfig stores credentials of the registries in a docker config.json, which both Docker
+// (DOCKER_CONFIG) and Podman (REGISTRY_AUTH_FILE) read. It returns an empty dir when no registry has credentials.
+func writeAuthConfig(upstreams map[string]*upstream) (string, error) {
+	type auth struct {
+		Auth string `json:"auth"`
+	}
+	auths := make(map[string]auth)
+
+	for _, u := range upstreams {
+		if u.Username == "" && u.Password == "" {
+			continue
+		}
+
+		auths[u.host()] = auth{Auth: base64.StdEncoding.EncodeToString([]byte(u.Username + ":" + u.Password))}
+	}
+
+	if len(auths) == 0 {
+		return "", nil
+	}
+
+	data, err := json.Marshal(map[string]any{"auths": auths})
+	if err != nil {
+		return "", fmt.Errorf("json.Marshal: %w", err)
+	}
+
+	dir, err := os.MkdirTemp("", "easyp-registry-auth-")
+	if err != nil {
+		return "", fmt.Errorf("os.MkdirTemp: %w", err)
+	}
+
+	err = os.WriteFile(filepath.Join(dir, "config.json"), data, 0o600)
+	if err != nil {
+		_ = os.RemoveAll(dir)
+
+		return "", fmt.Errorf("os.WriteFile: %w", err)
+	}
+
+	return dir, nil
+}
+
+// engineEnv returns the environment which points the container engine to the credentials.
+func engineEnv(authDir string) []string {
+	if authDir == "" {
+		return nil
+	}
+
+	return []string{
+		"DOCKER_CONFIG=" + authDir,
+		"REGISTRY_AUTH_FILE=" + filepath.Join(authDir, "config.json"),
+	}
+}
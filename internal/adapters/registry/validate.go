@@ -0,0 +1,319 @@
+package registry
+
+import (
+	"fmt"
+	"maps"
+	"math"
+	"path"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/easyp-tech/service/internal/core"
+)
+
+const networkNone = "none"
+
+var (
+	envNamePattern     = regexp.MustCompile(`^[A-Z_][A-Z0-9_]*$`)
+	userPattern        = regexp.MustCompile(`^([a-z_][a-z0-9_-]*|[0-9]+)(:([a-z_][a-z0-9_-]*|[0-9]+))?$`)
+	namePattern        = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9._-]*$`)
+	capabilityPattern  = regexp.MustCompile(`^[A-Z_]+$`)
+	mountPathPattern   = regexp.MustCompile(`^/[A-Za-z0-9._/-]+$`)
+	tmpfsOptionPattern = regexp.MustCompile(`^(ro|rw|noexec|nosuid|nodev|mode=[0-7]{3,4}|uid=[0-9]+|gid=[0-9]+|size=[0-9]+[bkmgBKMG]?)$`)
+)
+
+// Paths which can't be shadowed by tmpfs mounts.
+var reservedMountPaths = []string{"/proc", "/sys", "/dev"}
+
+type (
+	// Limits are the ceilings of plugin configs.
+	Limits struct {
+		// Networks lists the networks plugins may use, "none" is always allowed.
+		Networks []string
+		// MaxMemory is the ceiling of memory limits, e.g. "1g". Unlimited when empty.
+		MaxMemory string
+		// MaxCPUs is the ceiling of CPU limits. Unlimited when zero.
+		MaxCPUs float64
+	}
+
+	// validator checks plugin configs before they are stored and before every run.
+	validator struct {
+		networks  []string
+		maxMemory uint64
+		maxCPUs   float64
+	}
+)
+
+func newValidator(limits Limits) (*validator, error) {
+	v := &validator{
+		networks: append([]string{networkNone}, limits.Networks...),
+		maxCPUs:  limits.MaxCPUs,
+	}
+
+	for _, network := range limits.Networks {
+		if network == "host" || strings.HasPrefix(network, "container:") {
+			return nil, fmt.Errorf("network %q can't be allowed", network)
+		}
+	}
+
+	if limits.MaxMemory != "" {
+		maxMemory, err := parseBytes(limits.MaxMemory)
+		if err != nil {
+			return nil, fmt.Errorf("parseBytes: %w", err)
+		}
+
+		v.maxMemory = maxMemory
+	}
+
+	return v, nil
+}
+
+// validate checks every field of the plugin config, executors lists the enabled executors.
+// All problems are reported at once as core.ErrInvalidPluginConfig.
+func (v *validator) validate(cfg *PluginConfig, executors map[string]executor) error {
+	var problems []string
+
+	kind := cfg.Executor
+	if kind == "" {
+		kind = ExecutorDocker
+	}
+
+	if _, ok := executors[kind]; !ok {
+		problems = append(problems, fmt.Sprintf("executor %q is unknown or disabled", kind))
+	}
+
+	if cfg.Docker != nil {
+		problems = append(problems, v.docker(cfg.Docker)...)
+	}
+
+	if cfg.Local != nil {
+		problems = append(problems, v.local(cfg.Local)...)
+	}
+
+	if cfg.Wasm != nil {
+		problems = append(problems, v.wasm(cfg.Wasm)...)
+	}
+
+	problems = append(problems, secrets(cfg)...)
+
+	if len(problems) > 0 {
+		return fmt.Errorf("%w: %s", core.ErrInvalidPluginConfig, strings.Join(problems, "; "))
+	}
+
+	return nil
+}
+
+func (v *validator) docker(cfg *DockerConfig) []string {
+	var problems []string
+
+	if cfg.Network != "" && !slices.Contains(v.networks, cfg.Network) {
+		problems = append(problems, fmt.Sprintf("network %q is not allowed, allowed: %s", cfg.Network, strings.Join(v.networks, ", ")))
+	}
+
+	if cfg.Memory != "" {
+		problems = append(problems, v.memory("memory", cfg.Memory)...)
+	}
+
+	if cfg.CPUs != "" {
+		problems = append(problems, v.cpus("cpus", cfg.CPUs)...)
+	}
+
+	if cfg.User != "" && !userPattern.MatchString(cfg.User) {
+		problems = append(problems, fmt.Sprintf("user %q must be user[:group] names or ids", cfg.User))
+	}
+
+	problems = append(problems, envNames(cfg.Env)...)
+
+	if cfg.WorkingDir != "" && !isMountPath(cfg.WorkingDir) {
+		problems = append(problems, fmt.Sprintf("working_dir %q must be a clean absolute path", cfg.WorkingDir))
+	}
+
+	for _, mountPath := range slices.Sorted(maps.Keys(cfg.TmpFS)) {
+		problems = append(problems, tmpfs(mountPath, cfg.TmpFS[mountPath])...)
+	}
+
+	for option, value := range map[string]string{
+		"runtime":          cfg.Runtime,
+		"seccomp_profile":  cfg.SeccompProfile,
+		"apparmor_profile": cfg.AppArmorProfile,
+	} {
+		if value != "" && !namePattern.MatchString(value) {
+			problems = append(problems, fmt.Sprintf("%s %q is not a valid name", option, value))
+		}
+	}
+
+	for _, capability := range cfg.CapDrop {
+		if !capabilityPattern.MatchString(capability) {
+			problems = append(problems, fmt.Sprintf("cap_drop %q is not a capability name", capability))
+		}
+	}
+
+	if cfg.PidsLimit < 0 {
+		problems = append(problems, "pids_limit can't be negative")
+	}
+
+	return problems
+}
+
+func (v *validator) local(cfg *LocalConfig) []string {
+	var problems []string
+
+	if cfg.Binary == "" || !namePattern.MatchString(cfg.Binary) {
+		problems = append(problems, fmt.Sprintf("local binary %q is not a valid file name", cfg.Binary))
+	}
+
+	if cfg.Memory != "" {
+		problems = append(problems, v.memory("local memory", cfg.Memory)...)
+	}
+
+	if cfg.FileSize != "" {
+		if _, err := parseBytes(cfg.FileSize); err != nil {
+			problems = append(problems, fmt.Sprintf("local file_size %q is not a size", cfg.FileSize))
+		}
+	}
+
+	if cfg.CPUTime != "" {
+		problems = append(problems, duration("local cpu_time", cfg.CPUTime)...)
+	}
+
+	return append(problems, envNames(cfg.Env)...)
+}
+
+func (v *validator) wasm(cfg *WasmConfig) []string {
+	var problems []string
+
+	if cfg.Module != "" && !namePattern.MatchString(cfg.Module) {
+		problems = append(problems, fmt.Sprintf("wasm module %q is not a valid file name", cfg.Module))
+	}
+
+	if cfg.Memory != "" {
+		problems = append(problems, v.memory("wasm memory", cfg.Memory)...)
+	}
+
+	if cfg.CPUs != "" {
+		problems = append(problems, v.cpus("wasm cpus", cfg.CPUs)...)
+	}
+
+	if cfg.Timeout != "" {
+		problems = append(problems, duration("wasm timeout", cfg.Timeout)...)
+	}
+
+	return append(problems, envNames(cfg.Env)...)
+}
+
+func (v *validator) cpus(option, value string) []string {
+	cpus, err := strconv.ParseFloat(value, 64)
+	switch {
+	case err != nil || cpus <= 0 || math.IsInf(cpus, 0) || math.IsNaN(cpus):
+		return []string{fmt.Sprintf("%s %q is not a positive number", option, value)}
+	case v.maxCPUs > 0 && cpus > v.maxCPUs:
+		return []string{fmt.Sprintf("%s %s exceeds the limit %g", option, value, v.maxCPUs)}
+	}
+
+	return nil
+}
+
+func (v *validator) memory(option, value string) []string {
+	memory, err := parseBytes(value)
+	switch {
+	case err != nil:
+		return []string{fmt.Sprintf("%s %q is not a size", option, value)}
+	case v.maxMemory > 0 && memory > v.maxMemory:
+		return []string{fmt.Sprintf("%s %s exceeds the limit of %d bytes", option, value, v.maxMemory)}
+	}
+
+	return nil
+}
+
+func duration(option, value string) []string {
+	d, err := time.ParseDuration(value)
+	if err != nil || d <= 0 {
+		return []string{fmt.Sprintf("%s %q is not a positive duration", option, value)}
+	}
+
+	return nil
+}
+
+func envNames(env map[string]string) []string {
+	var problems []string
+	for _, name := range slices.Sorted(maps.Keys(env)) {
+		value := env[name]
+		if !envNamePattern.MatchString(name) {
+			problems = append(problems, fmt.Sprintf("env name %q must match %s", name, envNamePattern))
+		}
+
+		if strings.ContainsRune(value, 0) {
+			problems = append(problems, fmt.Sprintf("env %s contains a NUL byte", name))
+		}
+	}
+
+	return problems
+}
+
+func secrets(cfg *PluginConfig) []string {
+	var envs []map[string]string
+	if cfg.Docker != nil {
+		envs = append(envs, cfg.Docker.Env)
+	}
+
+	if cfg.Local != nil {
+		envs = append(envs, cfg.Local.Env)
+	}
+
+	if cfg.Wasm != nil {
+		envs = append(envs, cfg.Wasm.Env)
+	}
+
+	var problems []string
+	for _, env := range slices.Sorted(maps.Keys(cfg.Secrets)) {
+		name := cfg.Secrets[env]
+		if !envNamePattern.MatchString(env) {
+			problems = append(problems, fmt.Sprintf("secret env name %q must match %s", env, envNamePattern))
+		}
+
+		if !namePattern.MatchString(name) {
+			problems = append(problems, fmt.Sprintf("secret name %q is not a valid name", name))
+		}
+
+		for _, plain := range envs {
+			if _, ok := plain[env]; ok {
+				problems = append(problems, fmt.Sprintf("secret env %s is also set in env", env))
+			}
+		}
+	}
+
+	return problems
+}
+
+func tmpfs(mountPath, opts string) []string {
+	var problems []string
+
+	if !isMountPath(mountPath) {
+		problems = append(problems, fmt.Sprintf("tmpfs path %q must be a clean absolute path", mountPath))
+	}
+
+	for _, reserved := range reservedMountPaths {
+		if mountPath == reserved || strings.HasPrefix(mountPath, reserved+"/") {
+			problems = append(problems, fmt.Sprintf("tmpfs path %q shadows %s", mountPath, reserved))
+		}
+	}
+
+	if opts == "" {
+		return problems
+	}
+
+	for _, opt := range strings.Split(opts, ",") {
+		if !tmpfsOptionPattern.MatchString(opt) {
+			problems = append(problems, fmt.Sprintf("tmpfs option %q of %s is not allowed", opt, mountPath))
+		}
+	}
+
+	return problems
+}
+
+func isMountPath(p string) bool {
+	return mountPathPattern.MatchString(p) && path.Clean(p) == p
+}
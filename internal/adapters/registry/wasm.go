@@ -0,0 +1,261 @@
+package registry
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/tetratelabs/wazero"
+	"github.com/tetratelabs/wazero/imports/wasi_snapshot_preview1"
+)
+
+var (
+	_ executor = &wasmExecutor{}
+	_ puller   = &wasmExecutor{}
+)
+
+// Default limits of the wasm executor.
+const (
+	defaultWasmMemory  = "128m"
+	defaultWasmCPUs    = "1.0"
+	defaultWasmTimeout = 30 * time.Second
+
+	// maxWasmModulesSize bounds the modules pulled from the registry kept in memory.
+	maxWasmModulesSize = 512 << 20
+
+	wasmPageSize = 64 << 10
+	maxWasmPages = 65536
+)
+
+// Media types of wasm layers.
+var wasmMediaTypes = []string{
+	"application/wasm",
+	"application/vnd.wasm.content.layer.v1+wasm",
+	"application/vnd.module.wasm.content.layer.v1+wasm",
+}
+
+var (
+	errNoWasmLayer       = errors.New("image has no wasm layer")
+	errNoWasmModulesDir  = errors.New("wasm modules dir is not configured")
+	errCPUBudgetExceeded = errors.New("cpu budget exceeded")
+)
+
+// wasmExecutor runs plugins compiled to WASI in-process.
+// A module runs on a single goroutine, its CPU time is limited to cpus * timeout.
+type wasmExecutor struct {
+	dir   string
+	cache wazero.CompilationCache
+
+	mu      sync.Mutex
+	modules map[string][]byte // Digest -> module, for modules pulled from the registry.
+	// recent are digests of the modules, the least recently used first.
+	recent []string
+	size   int
+}
+
+func newWasmExecutor(dir string) *wasmExecutor {
+	return &wasmExecutor{
+		dir:     dir,
+		cache:   wazero.NewCompilationCache(),
+		modules: make(map[string][]byte),
+	}
+}
+
+// execute implements executor.
+func (e *wasmExecutor) execute(ctx context.Context, p *plugin, input []byte) ([]byte, error) {
+	wasmConfig := p.pluginConfig.Wasm
+	if wasmConfig == nil {
+		wasmConfig = &WasmConfig{}
+	}
+
+	memory := wasmConfig.Memory
+	if memory == "" {
+		memory = defaultWasmMemory
+	}
+
+	memoryBytes, err := parseBytes(memory)
+	if err != nil {
+		return nil, fmt.Errorf("parseBytes: %w", err)
+	}
+
+	timeout := defaultWasmTimeout
+	if wasmConfig.Timeout != "" {
+		timeout, err = time.ParseDuration(wasmConfig.Timeout)
+		if err != nil {
+			return nil, fmt.Errorf("time.ParseDuration: %w", err)
+		}
+	}
+
+	cpus := wasmConfig.CPUs
+	if cpus == "" {
+		cpus = defaultWasmCPUs
+	}
+
+	cpuShare, err := strconv.ParseFloat(cpus, 64)
+	if err != nil {
+		return nil, fmt.Errorf("strconv.ParseFloat: %w", err)
+	}
+
+	module, err := e.module(ctx, p, wasmConfig.Module)
+	if err != nil {
+		return nil, fmt.Errorf("e.module: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	runtimeConfig := wazero.NewRuntimeConfig().
+		WithCompilationCache(e.cache).
+		WithMemoryLimitPages(uint32(min(memoryBytes/wasmPageSize, maxWasmPages))).
+		WithCloseOnContextDone(true)
+
+	runtime := wazero.NewRuntimeWithConfig(ctx, runtimeConfig)
+	defer runtime.Close(ctx) //nolint:errcheck // Nothing to do with the error.
+
+	wasi_snapshot_preview1.MustInstantiate(ctx, runtime)
+
+	compiled, err := runtime.CompileModule(ctx, module)
+	if err != nil {
+		return nil, fmt.Errorf("runtime.CompileModule: %w", err)
+	}
+
+	var stdout, stderr bytes.Buffer
+	moduleConfig := wazero.NewModuleConfig().
+		WithName("").
+		WithArgs("protoc-gen-" + p.Name).
+		WithStdin(bytes.NewReader(input)).
+		WithStdout(&stdout).
+		WithStderr(&stderr)
+	for key, value := range wasmConfig.Env {
+		moduleConfig = moduleConfig.WithEnv(key, value)
+	}
+	for key, value := range p.secrets {
+		moduleConfig = moduleConfig.WithEnv(key, value)
+	}
+
+	instantiate := func(ctx context.Context) error {
+		_, err := runtime.InstantiateModule(ctx, compiled, moduleConfig)
+		return err //nolint:wrapcheck // Wrapped by the caller.
+	}
+
+	// A single goroutine can't use more than a core, the timeout is the budget then.
+	if cpuShare < 1 {
+		err = runWithCPUBudget(ctx, time.Duration(cpuShare*float64(timeout)), instantiate)
+	} else {
+		err = instantiate(ctx)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("plugin execution failed: %s, stderr: %s", err, stderr.String())
+	}
+
+	return stdout.Bytes(), nil
+}
+
+// pull implements puller, the module is downloaded into the cache.
+func (e *wasmExecutor) pull(ctx context.Context, p *plugin) error {
+	name := ""
+	if p.pluginConfig.Wasm != nil {
+		name = p.pluginConfig.Wasm.Module
+	}
+
+	_, err := e.module(ctx, p, name)
+	if err != nil {
+		return fmt.Errorf("e.module: %w", err)
+	}
+
+	return nil
+}
+
+// module returns the wasm module from the local store or pulls it from the registry.
+func (e *wasmExecutor) module(ctx context.Context, p *plugin, name string) ([]byte, error) {
+	if name != "" {
+		if e.dir == "" {
+			return nil, fmt.Errorf("module %s: %w", name, errNoWasmModulesDir)
+		}
+
+		path, err := pluginFile(e.dir, p, name)
+		if err != nil {
+			return nil, fmt.Errorf("pluginFile: %w", err)
+		}
+
+		module, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("os.ReadFile: %w", err)
+		}
+
+		return module, nil
+	}
+
+	oci := p.ref.upstream.oci
+	repository := p.ref.repository
+	reference := p.ref.reference
+	if p.digest != "" {
+		reference = p.digest
+	}
+
+	manifest, _, err := oci.manifest(ctx, repository, reference)
+	if err != nil {
+		return nil, fmt.Errorf("oci.manifest: %w", err)
+	}
+
+	idx := slices.IndexFunc(manifest.Layers, func(layer ociDescriptor) bool {
+		return slices.Contains(wasmMediaTypes, layer.MediaType)
+	})
+	if idx == -1 {
+		return nil, fmt.Errorf("%w: %s:%s", errNoWasmLayer, repository, reference)
+	}
+	digest := manifest.Layers[idx].Digest
+
+	module, ok := e.cached(digest)
+	if ok {
+		return module, nil
+	}
+
+	module, err = oci.blob(ctx, repository, digest)
+	if err != nil {
+		return nil, fmt.Errorf("oci.blob: %w", err)
+	}
+
+	e.store(digest, module)
+
+	return module, nil
+}
+
+func (e *wasmExecutor) cached(digest string) ([]byte, bool) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	module, ok := e.modules[digest]
+	if ok {
+		e.recent = append(slices.DeleteFunc(e.recent, func(d string) bool { return d == digest }), digest)
+	}
+
+	return module, ok
+}
+
+// store caches the module, the least recently used modules are evicted to fit maxWasmModulesSize.
+func (e *wasmExecutor) store(digest string, module []byte) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if _, ok := e.modules[digest]; ok || len(module) > maxWasmModulesSize {
+		return
+	}
+
+	for e.size+len(module) > maxWasmModulesSize {
+		evicted := e.recent[0]
+		e.recent = e.recent[1:]
+		e.size -= len(e.modules[evicted])
+		delete(e.modules, evicted)
+	}
+
+	e.modules[digest] = module
+	e.recent = append(e.recent, digest)
+	e.size += len(module)
+}
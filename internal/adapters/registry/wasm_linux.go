@@ -0,0 +1,75 @@
+package registry
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"runtime"
+	"time"
+
+	"golang.org/x/sys/unix"
+)
+
+// cpuBudgetInterval is how often the CPU time of a module is checked.
+const cpuBudgetInterval = 10 * time.Millisecond
+
+// runWithCPUBudget runs fn on a locked thread and cancels its context when the CPU time
+// of the thread exceeds the budget, wazero closes the module on the cancellation.
+func runWithCPUBudget(ctx context.Context, budget time.Duration, fn func(context.Context) error) error {
+	runtime.LockOSThread()
+	defer runtime.UnlockOSThread()
+
+	// MAKE_THREAD_CPUCLOCK(tid, CPUCLOCK_SCHED) of the kernel.
+	clock := int32((^unix.Gettid())<<3 | 6) //nolint:gosec // Thread ids fit the clock id.
+	cpuTime := func() (time.Duration, error) {
+		var ts unix.Timespec
+		err := unix.ClockGettime(clock, &ts)
+		if err != nil {
+			return 0, fmt.Errorf("unix.ClockGettime: %w", err)
+		}
+
+		return time.Duration(ts.Nano()), nil
+	}
+
+	start, err := cpuTime()
+	if err != nil {
+		return fmt.Errorf("cpuTime: %w", err)
+	}
+
+	ctx, cancel := context.WithCancelCause(ctx)
+	defer cancel(nil)
+
+	done := make(chan struct{})
+	defer close(done)
+
+	go func() {
+		ticker := time.NewTicker(cpuBudgetInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-done:
+				return
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				used, err := cpuTime()
+				switch {
+				case err != nil:
+					cancel(err)
+					return
+				case used-start > budget:
+					cancel(errCPUBudgetExceeded)
+					return
+				}
+			}
+		}
+	}()
+
+	err = fn(ctx)
+	if cause := context.Cause(ctx); errors.Is(cause, errCPUBudgetExceeded) {
+		return fmt.Errorf("%w: %s", errCPUBudgetExceeded, budget)
+	}
+
+	return err
+}
@@ -0,0 +1,13 @@
+//go:build !linux
+
+package registry
+
+import (
+	"context"
+	"time"
+)
+
+// runWithCPUBudget runs fn, there is no per-thread CPU clock, so only the timeout bounds the CPU time.
+func runWithCPUBudget(ctx context.Context, _ time.Duration, fn func(context.Context) error) error {
+	return fn(ctx)
+}
@@ -0,0 +1,56 @@
+package api
+
+import (
+	"context"
+	"crypto/subtle"
+	"errors"
+	"slices"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+
+	"github.com/easyp-tech/service/api/web/v1"
+)
+
+var (
+	errAdminDisabled   = errors.New("admin calls are disabled, the admin token is not configured")
+	errUnauthenticated = errors.New("admin token is required")
+)
+
+// adminMethods change the registry or expose its secrets, they require the admin token.
+// The gateway forwards the Authorization header, so HTTP calls are checked the same way.
+var adminMethods = []string{
+	web.ServiceAPI_RegisterPlugin_FullMethodName,
+	web.ServiceAPI_Secrets_FullMethodName,
+	web.ServiceAPI_PutSecret_FullMethodName,
+	web.ServiceAPI_DeleteSecret_FullMethodName,
+	web.ServiceAPI_AddSignature_FullMethodName,
+	web.ServiceAPI_SyncCatalog_FullMethodName,
+	web.ServiceAPI_PutModule_FullMethodName,
+	web.ServiceAPI_RunConformance_FullMethodName,
+}
+
+// adminInterceptor rejects admin calls without "authorization: Bearer <token>".
+// Admin calls are disabled when the token is empty.
+func adminInterceptor(token string) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		if !slices.Contains(adminMethods, info.FullMethod) {
+			return handler(ctx, req)
+		}
+
+		if token == "" {
+			return nil, errAdminDisabled
+		}
+
+		md, _ := metadata.FromIncomingContext(ctx)
+		for _, value := range md.Get("authorization") {
+			given, ok := strings.CutPrefix(value, "Bearer ")
+			if ok && subtle.ConstantTimeCompare([]byte(given), []byte(token)) == 1 {
+				return handler(ctx, req)
+			}
+		}
+
+		return nil, errUnauthenticated
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"log/slog"
 
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/sipki-tech/dev-platform/grpc_helper"
@@ -14,8 +15,10 @@ import (
 	"google.golang.org/grpc/codes"
 	healthpb "google.golang.org/grpc/health/grpc_health_v1"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
 
 	"github.com/easyp-tech/service/api/generator/v1"
+	"github.com/easyp-tech/service/api/web/v1"
 	"github.com/easyp-tech/service/internal/core"
 )
 
@@ -26,40 +29,221 @@ type API struct {
 	app *core.Core
 }
 
-// New creates and returns gRPC server.
-func New(ctx context.Context, m metrics.Metrics, applications *core.Core, reg *prometheus.Registry, namespace string) *grpc.Server {
-	log := logger.FromContext(ctx)
+// New creates and returns gRPC server, GenerateCode calls are recorded when the recorder isn't nil.
+// Admin calls require the admin token, they are disabled when it is empty.
+func New(ctx context.Context, m metrics.Metrics, applications *core.Core, reg *prometheus.Registry, namespace string, recorder *Recorder, adminToken string) *grpc.Server {
+	log := slog.New(redactHandler{logger.FromContext(ctx).Handler()})
 	subsystem := "api"
 
 	grpcMetrics := grpc_helper.NewServerMetrics(reg, namespace, subsystem)
 
+	unary := []grpc.UnaryServerInterceptor{adminInterceptor(adminToken)}
+	if recorder != nil {
+		unary = append(unary, recorder.interceptor())
+	}
+
 	srv, health := grpc_helper.NewServer(m, log, grpcMetrics, apiError,
-		[]grpc.UnaryServerInterceptor{},
+		unary,
 		[]grpc.StreamServerInterceptor{},
 	)
 	health.SetServingStatus(generator.ServiceAPI_ServiceDesc.ServiceName, healthpb.HealthCheckResponse_SERVING)
+	health.SetServingStatus(web.ServiceAPI_ServiceDesc.ServiceName, healthpb.HealthCheckResponse_SERVING)
 
 	api := &API{
 		app: applications,
 	}
 	generator.RegisterServiceAPIServer(srv, api)
+	web.RegisterServiceAPIServer(srv, &webAPI{app: applications})
 
 	return srv
 }
 
 // GenerateCode implements generator.PluginGeneratorServiceServer.
 func (api *API) GenerateCode(ctx context.Context, request *generator.GenerateCodeRequest) (*generator.GenerateCodeResponse, error) {
-	resp, err := api.app.Generate(ctx, core.GenerateCodeRequest{
-		PluginName: request.PluginName,
-		Payload:    request.CodeGeneratorRequest,
-	})
+	resp, err := api.app.Generate(ctx, generateCodeRequest(request))
 	if err != nil {
 		return nil, fmt.Errorf("api.app.Generate: %w", err)
 	}
 
+	return generateCodeResponse(resp), nil
+}
+
+// GenerateCodeBatch implements generator.ServiceAPIServer.
+func (api *API) GenerateCodeBatch(ctx context.Context, request *generator.GenerateCodeBatchRequest) (*generator.GenerateCodeBatchResponse, error) {
+	reqs := make([]core.GenerateCodeRequest, len(request.Requests))
+	for i, req := range request.Requests {
+		reqs[i] = generateCodeRequest(req)
+	}
+
+	resps, err := api.app.GenerateBatch(ctx, reqs)
+	if err != nil {
+		return nil, fmt.Errorf("api.app.GenerateBatch: %w", err)
+	}
+
+	responses := make([]*generator.GenerateCodeResponse, len(resps))
+	for i := range resps {
+		responses[i] = generateCodeResponse(&resps[i])
+	}
+
+	return &generator.GenerateCodeBatchResponse{
+		Responses: responses,
+	}, nil
+}
+
+// DiffGeneration implements generator.ServiceAPIServer.
+func (api *API) DiffGeneration(ctx context.Context, request *generator.DiffGenerationRequest) (*generator.DiffGenerationResponse, error) {
+	if request.Request == nil {
+		return nil, fmt.Errorf("%w: request must be set", core.ErrInvalidArgument)
+	}
+
+	resp, err := api.app.DiffGeneration(ctx, core.DiffGenerationRequest{
+		Base:    request.BasePlugin,
+		Target:  request.TargetPlugin,
+		Request: generateCodeRequest(request.Request),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("api.app.DiffGeneration: %w", err)
+	}
+
+	files := make([]*generator.FileDiff, len(resp.Files))
+	for i, file := range resp.Files {
+		files[i] = &generator.FileDiff{
+			Name:         file.Name,
+			Status:       file.Status,
+			Diff:         file.Diff,
+			LinesAdded:   int32(file.LinesAdded),   //nolint:gosec // Lines of a generated file fit int32.
+			LinesRemoved: int32(file.LinesRemoved), //nolint:gosec // Lines of a generated file fit int32.
+		}
+	}
+
+	//nolint:gosec // Counts of generated files and lines fit int32.
+	return &generator.DiffGenerationResponse{
+		Files: files,
+		Summary: &generator.DiffSummary{
+			Added:        int32(resp.Summary.Added),
+			Removed:      int32(resp.Summary.Removed),
+			Modified:     int32(resp.Summary.Modified),
+			Unchanged:    int32(resp.Summary.Unchanged),
+			LinesAdded:   int32(resp.Summary.LinesAdded),
+			LinesRemoved: int32(resp.Summary.LinesRemoved),
+		},
+		Base:   generateCodeResponse(&resp.Base),
+		Target: generateCodeResponse(&resp.Target),
+	}, nil
+}
+
+// GenerateFromSources implements generator.ServiceAPIServer.
+func (api *API) GenerateFromSources(ctx context.Context, request *generator.GenerateFromSourcesRequest) (*generator.GenerateFromSourcesResponse, error) {
+	req := core.GenerateFromSourcesRequest{
+		Files:          make([]core.SourceFile, len(request.Files)),
+		Modules:        request.Modules,
+		FileToGenerate: request.FileToGenerate,
+		Plugins:        make([]core.SourcePlugin, len(request.Plugins)),
+	}
+	for i, file := range request.Files {
+		req.Files[i] = core.SourceFile{Path: file.Path, Content: file.Content}
+	}
+	for i, plugin := range request.Plugins {
+		req.Plugins[i] = core.SourcePlugin{
+			PluginName:  plugin.PluginName,
+			Parameter:   plugin.Parameter,
+			PostProcess: postProcess(plugin.PostProcess),
+		}
+	}
+
+	resp, err := api.app.GenerateFromSources(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("api.app.GenerateFromSources: %w", err)
+	}
+
+	response := &generator.GenerateFromSourcesResponse{
+		Responses: make([]*generator.GenerateCodeResponse, len(resp.Responses)),
+		Errors:    make([]*generator.CompileError, len(resp.Errors)),
+	}
+	for i := range resp.Responses {
+		response.Responses[i] = generateCodeResponse(&resp.Responses[i])
+	}
+	for i, compileErr := range resp.Errors {
+		response.Errors[i] = &generator.CompileError{
+			File:    compileErr.File,
+			Line:    int32(compileErr.Line),   //nolint:gosec // Lines of sources within the message size.
+			Column:  int32(compileErr.Column), //nolint:gosec // Columns of sources within the message size.
+			Message: compileErr.Message,
+		}
+	}
+
+	return response, nil
+}
+
+// MissingDescriptors implements generator.ServiceAPIServer.
+func (api *API) MissingDescriptors(ctx context.Context, request *generator.MissingDescriptorsRequest) (*generator.MissingDescriptorsResponse, error) {
+	missing, err := api.app.MissingDescriptors(ctx, request.Hashes)
+	if err != nil {
+		return nil, fmt.Errorf("api.app.MissingDescriptors: %w", err)
+	}
+
+	return &generator.MissingDescriptorsResponse{
+		Hashes: missing,
+	}, nil
+}
+
+// UploadDescriptors implements generator.ServiceAPIServer.
+func (api *API) UploadDescriptors(ctx context.Context, request *generator.UploadDescriptorsRequest) (*generator.UploadDescriptorsResponse, error) {
+	hashes, err := api.app.UploadDescriptors(ctx, request.ProtoFile)
+	if err != nil {
+		return nil, fmt.Errorf("api.app.UploadDescriptors: %w", err)
+	}
+
+	return &generator.UploadDescriptorsResponse{
+		Hashes: hashes,
+	}, nil
+}
+
+func generateCodeRequest(request *generator.GenerateCodeRequest) core.GenerateCodeRequest {
+	return core.GenerateCodeRequest{
+		PluginName:        request.PluginName,
+		Payload:           request.CodeGeneratorRequest,
+		ProtoFileHashes:   request.ProtoFileHashes,
+		Plugins:           request.Plugins,
+		PostProcess:       postProcess(request.PostProcess),
+		Modules:           request.Modules,
+		VerifyDeterminism: request.VerifyDeterminism,
+	}
+}
+
+func postProcess(p *generator.PostProcess) *core.PostProcess {
+	if p == nil {
+		return nil
+	}
+
+	return &core.PostProcess{
+		Header:     p.Header,
+		PathPrefix: p.PathPrefix,
+		Include:    p.Include,
+		Exclude:    p.Exclude,
+		GoFormat:   p.GoFormat,
+	}
+}
+
+func generateCodeResponse(resp *core.GenerateCodeResponse) *generator.GenerateCodeResponse {
 	return &generator.GenerateCodeResponse{
 		CodeGeneratorResponse: resp.Payload,
-	}, nil
+		Warnings:              resp.Warnings,
+		Determinism:           determinismCheck(resp.Determinism),
+	}
+}
+
+func determinismCheck(check *core.DeterminismCheck) *generator.DeterminismCheck {
+	if check == nil {
+		return nil
+	}
+
+	return &generator.DeterminismCheck{
+		Deterministic: check.Badge() == core.DeterminismPassed,
+		Differences:   check.Differences,
+		Error:         check.Error,
+		CheckedAt:     timestamppb.New(check.CheckedAt),
+	}
 }
 
 func apiError(err error) *status.Status {
@@ -73,8 +257,30 @@ func apiError(err error) *status.Status {
 		code = codes.NotFound
 	case errors.Is(err, core.ErrInvalidPluginName):
 		code = codes.InvalidArgument
+	case errors.Is(err, core.ErrMissingDescriptor):
+		code = codes.FailedPrecondition
+	case errors.Is(err, core.ErrInvalidPluginConfig):
+		code = codes.FailedPrecondition
+	case errors.Is(err, core.ErrAlreadyExists):
+		code = codes.AlreadyExists
+	case errors.Is(err, core.ErrSecretsDisabled):
+		code = codes.FailedPrecondition
+	case errors.Is(err, core.ErrInvalidArgument):
+		code = codes.InvalidArgument
+	case errors.Is(err, core.ErrUnsignedImage):
+		code = codes.FailedPrecondition
+	case errors.Is(err, core.ErrInvalidSignature):
+		code = codes.PermissionDenied
+	case errors.Is(err, core.ErrIncompatiblePlugin):
+		code = codes.FailedPrecondition
+	case errors.Is(err, core.ErrNoFixtures):
+		code = codes.FailedPrecondition
 	case errors.Is(err, core.ErrGenerationFailed):
 		code = codes.Internal
+	case errors.Is(err, errUnauthenticated):
+		code = codes.Unauthenticated
+	case errors.Is(err, errAdminDisabled):
+		code = codes.PermissionDenied
 	case errors.Is(err, context.DeadlineExceeded):
 		code = codes.DeadlineExceeded
 	case errors.Is(err, context.Canceled):
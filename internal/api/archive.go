@@ -0,0 +1,442 @@
+package api
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
+	"path"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/pluginpb"
+
+	"github.com/easyp-tech/service/api/generator/v1"
+	"github.com/easyp-tech/service/api/web/v1"
+	"github.com/easyp-tech/service/internal/core"
+)
+
+// Archive formats.
+const (
+	archiveTarGz = "tar.gz"
+	archiveZip   = "zip"
+)
+
+const (
+	// archivePath is the endpoint of the archive handler on the gateway.
+	archivePath = "/v1/archive"
+	// maxDescriptorSetSize limits the request body to the default message size of the gRPC server,
+	// larger sets are uploaded with UploadDescriptors and referenced by proto_file_hash.
+	maxDescriptorSetSize = 4 << 20
+	// maxArchiveResponseSize limits the generated code, it exceeds the default 4 MiB.
+	maxArchiveResponseSize = 256 << 20
+	// checksumsFile lists SHA-256 sums of the generated files in the sha256sum format.
+	checksumsFile = "SHA256SUMS"
+	// archiveChecksumHeader is the SHA-256 of the whole archive.
+	archiveChecksumHeader = "X-Archive-Sha256"
+	// determinismHeader is the result of verify_determinism.
+	determinismHeader = "X-Deterministic"
+)
+
+var errInsertionPoint = errors.New("insertion point not found")
+
+// Gateway registers the web API and the archive endpoint on the gateway mux.
+func Gateway(ctx context.Context, mux *runtime.ServeMux, conn *grpc.ClientConn) error {
+	err := web.RegisterServiceAPIHandler(ctx, mux, conn)
+	if err != nil {
+		return fmt.Errorf("web.RegisterServiceAPIHandler: %w", err)
+	}
+
+	h := &archiveHandler{mux: mux, client: generator.NewServiceAPIClient(conn)}
+	err = mux.HandlePath(http.MethodPost, archivePath, h.serve)
+	if err != nil {
+		return fmt.Errorf("mux.HandlePath: %w", err)
+	}
+
+	return nil
+}
+
+// archiveHandler generates code from a FileDescriptorSet and returns the files as an archive.
+// It calls GenerateCode through the gateway connection, so the call passes the interceptors of the server.
+//
+// The body is a binary FileDescriptorSet, or its JSON form with the application/json content type.
+// Query parameters:
+//   - plugin: the plugin name, e.g. "protobuf/go:v1.36.9";
+//   - parameter: CodeGeneratorRequest.parameter;
+//   - file: files to generate, repeated, files which no other file of the set imports when empty;
+//   - format: "tar.gz" (default) or "zip";
+//   - proto_file_hash, module, plugins, verify_determinism: the GenerateCodeRequest fields;
+//   - header, path_prefix, include, exclude, go_format: the post_process override.
+type archiveHandler struct {
+	mux    *runtime.ServeMux
+	client generator.ServiceAPIClient
+}
+
+func (h *archiveHandler) serve(w http.ResponseWriter, r *http.Request, _ map[string]string) {
+	query := r.URL.Query()
+
+	format := query.Get("format")
+	switch format {
+	case "":
+		format = archiveTarGz
+	case archiveTarGz, archiveZip:
+	default:
+		writeError(w, fmt.Errorf("%w: format %q must be %s or %s", core.ErrInvalidArgument, format, archiveTarGz, archiveZip))
+
+		return
+	}
+
+	set, err := readDescriptorSet(w, r)
+	if err != nil {
+		writeError(w, fmt.Errorf("readDescriptorSet: %w", err))
+
+		return
+	}
+
+	files := query["file"]
+	if len(files) == 0 {
+		files = rootFiles(set.File)
+	}
+
+	req, err := archiveRequest(query, set, files)
+	if err != nil {
+		writeError(w, fmt.Errorf("archiveRequest: %w", err))
+
+		return
+	}
+
+	ctx, err := runtime.AnnotateContext(r.Context(), h.mux, r, generator.ServiceAPI_GenerateCode_FullMethodName,
+		runtime.WithHTTPPathPattern(archivePath))
+	if err != nil {
+		writeError(w, fmt.Errorf("runtime.AnnotateContext: %w", err))
+
+		return
+	}
+
+	resp, err := h.client.GenerateCode(ctx, req, grpc.MaxCallRecvMsgSize(maxArchiveResponseSize))
+	if err != nil {
+		writeError(w, err)
+
+		return
+	}
+
+	payload := resp.GetCodeGeneratorResponse()
+	if payload.GetError() != "" {
+		writeError(w, fmt.Errorf("%w: %s", core.ErrGenerationFailed, payload.GetError()))
+
+		return
+	}
+
+	outputs, err := outputFiles(payload.File)
+	if err != nil {
+		writeError(w, fmt.Errorf("outputFiles: %w", err))
+
+		return
+	}
+
+	data, err := writeArchive(format, outputs)
+	if err != nil {
+		writeError(w, fmt.Errorf("writeArchive: %w", err))
+
+		return
+	}
+
+	sum := sha256.Sum256(data)
+	filename := strings.NewReplacer("/", "-", ":", "-").Replace(req.PluginName) + "." + format
+
+	w.Header().Set("Content-Type", archiveContentType(format))
+	w.Header().Set("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": filename}))
+	w.Header().Set(archiveChecksumHeader, hex.EncodeToString(sum[:]))
+	for _, warning := range resp.Warnings {
+		w.Header().Add("Warning", `199 - "`+strings.ReplaceAll(warning, `"`, `'`)+`"`)
+	}
+
+	if resp.Determinism != nil {
+		w.Header().Set(determinismHeader, strconv.FormatBool(resp.Determinism.Deterministic))
+	}
+
+	_, _ = w.Write(data)
+}
+
+// archiveRequest builds GenerateCodeRequest of the query parameters.
+func archiveRequest(query url.Values, set *descriptorpb.FileDescriptorSet, files []string) (*generator.GenerateCodeRequest, error) {
+	req := &generator.GenerateCodeRequest{
+		PluginName: query.Get("plugin"),
+		CodeGeneratorRequest: &pluginpb.CodeGeneratorRequest{
+			FileToGenerate: files,
+			Parameter:      proto.String(query.Get("parameter")),
+			ProtoFile:      set.File,
+		},
+		ProtoFileHashes: query["proto_file_hash"],
+		Plugins:         query["plugins"],
+		Modules:         query["module"],
+	}
+
+	var err error
+	if query.Has("verify_determinism") {
+		req.VerifyDeterminism, err = strconv.ParseBool(query.Get("verify_determinism"))
+		if err != nil {
+			return nil, fmt.Errorf("%w: verify_determinism: %s", core.ErrInvalidArgument, err)
+		}
+	}
+
+	postProcess := &generator.PostProcess{Include: query["include"], Exclude: query["exclude"]}
+	if query.Has("header") {
+		postProcess.Header = proto.String(query.Get("header"))
+	}
+
+	if query.Has("path_prefix") {
+		postProcess.PathPrefix = proto.String(query.Get("path_prefix"))
+	}
+
+	if query.Has("go_format") {
+		goFormat, err := strconv.ParseBool(query.Get("go_format"))
+		if err != nil {
+			return nil, fmt.Errorf("%w: go_format: %s", core.ErrInvalidArgument, err)
+		}
+
+		postProcess.GoFormat = proto.Bool(goFormat)
+	}
+
+	if !proto.Equal(postProcess, &generator.PostProcess{}) {
+		req.PostProcess = postProcess
+	}
+
+	return req, nil
+}
+
+// readDescriptorSet decodes the body by its content type.
+func readDescriptorSet(w http.ResponseWriter, r *http.Request) (*descriptorpb.FileDescriptorSet, error) {
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxDescriptorSetSize))
+	if err != nil {
+		return nil, fmt.Errorf("%w: body: %s", core.ErrInvalidArgument, err)
+	}
+
+	set := &descriptorpb.FileDescriptorSet{}
+	mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if mediaType == "application/json" {
+		err = protojson.Unmarshal(body, set)
+	} else {
+		err = proto.Unmarshal(body, set)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%w: file descriptor set: %s", core.ErrInvalidArgument, err)
+	}
+
+	if len(set.File) == 0 {
+		return nil, fmt.Errorf("%w: file descriptor set is empty", core.ErrInvalidArgument)
+	}
+
+	return set, nil
+}
+
+// rootFiles returns names of the files which aren't imported by other files of the set.
+func rootFiles(files []*descriptorpb.FileDescriptorProto) []string {
+	imported := make(map[string]bool)
+	for _, file := range files {
+		for _, dependency := range file.Dependency {
+			imported[dependency] = true
+		}
+	}
+
+	var roots []string
+	for _, file := range files {
+		if !imported[file.GetName()] {
+			roots = append(roots, file.GetName())
+		}
+	}
+
+	return roots
+}
+
+type outputFile struct {
+	name    string
+	content string
+}
+
+// outputFiles joins continuations of files and applies insertion points the way protoc does:
+// the content is inserted before the line with @@protoc_insertion_point(NAME), with its indentation.
+func outputFiles(files []*pluginpb.CodeGeneratorResponse_File) ([]outputFile, error) {
+	var joined []*pluginpb.CodeGeneratorResponse_File
+	for _, file := range files {
+		switch {
+		case file.Name == nil && len(joined) == 0:
+			return nil, fmt.Errorf("%w: the first file has no name", core.ErrGenerationFailed)
+		case file.Name == nil:
+			last := joined[len(joined)-1]
+			last.Content = proto.String(last.GetContent() + file.GetContent())
+		default:
+			joined = append(joined, proto.CloneOf(file))
+		}
+	}
+
+	var outputs []outputFile
+	for _, file := range joined {
+		switch {
+		case file.GetInsertionPoint() != "":
+			idx := slices.IndexFunc(outputs, func(o outputFile) bool { return o.name == file.GetName() })
+			if idx == -1 {
+				return nil, fmt.Errorf("%w: %s: %w", core.ErrGenerationFailed, file.GetName(), errInsertionPoint)
+			}
+
+			content, err := insert(outputs[idx].content, file.GetInsertionPoint(), file.GetContent())
+			if err != nil {
+				return nil, fmt.Errorf("%w: %s: %w", core.ErrGenerationFailed, file.GetName(), err)
+			}
+
+			outputs[idx].content = content
+		case !validFileName(file.GetName()):
+			return nil, fmt.Errorf("%w: file name %q must be a relative path inside the output directory", core.ErrGenerationFailed, file.GetName())
+		default:
+			outputs = append(outputs, outputFile{name: file.GetName(), content: file.GetContent()})
+		}
+	}
+
+	return outputs, nil
+}
+
+func validFileName(name string) bool {
+	return name != "" && name == path.Clean(name) && !path.IsAbs(name) && name != ".." && !strings.HasPrefix(name, "../")
+}
+
+func insert(content, point, insertion string) (string, error) {
+	marker := "@@protoc_insertion_point(" + point + ")"
+
+	idx := strings.Index(content, marker)
+	if idx == -1 {
+		return "", fmt.Errorf("%w: %s", errInsertionPoint, point)
+	}
+
+	lineStart := strings.LastIndexByte(content[:idx], '\n') + 1
+	indent := content[lineStart:idx]
+	indent = indent[:len(indent)-len(strings.TrimLeft(indent, " \t"))]
+
+	var b strings.Builder
+	for line := range strings.Lines(insertion) {
+		if line != "\n" {
+			b.WriteString(indent)
+		}
+
+		b.WriteString(line)
+	}
+
+	if !strings.HasSuffix(insertion, "\n") && insertion != "" {
+		b.WriteString("\n")
+	}
+
+	return content[:lineStart] + b.String() + content[lineStart:], nil
+}
+
+// writeArchive packs the files with the checksums file, entries have a fixed time so archives are reproducible.
+func writeArchive(format string, files []outputFile) ([]byte, error) {
+	var sums strings.Builder
+	for _, file := range files {
+		if file.name == checksumsFile {
+			return nil, fmt.Errorf("%w: generated file %s conflicts with the checksums file", core.ErrGenerationFailed, checksumsFile)
+		}
+
+		sum := sha256.Sum256([]byte(file.content))
+		fmt.Fprintf(&sums, "%s  %s\n", hex.EncodeToString(sum[:]), file.name)
+	}
+
+	files = append(files, outputFile{name: checksumsFile, content: sums.String()})
+	modTime := time.Unix(0, 0).UTC()
+
+	var buf bytes.Buffer
+	switch format {
+	case archiveZip:
+		zw := zip.NewWriter(&buf)
+		for _, file := range files {
+			fw, err := zw.CreateHeader(&zip.FileHeader{Name: file.name, Method: zip.Deflate, Modified: modTime})
+			if err != nil {
+				return nil, fmt.Errorf("zw.CreateHeader: %w", err)
+			}
+
+			_, err = io.WriteString(fw, file.content)
+			if err != nil {
+				return nil, fmt.Errorf("io.WriteString: %w", err)
+			}
+		}
+
+		err := zw.Close()
+		if err != nil {
+			return nil, fmt.Errorf("zw.Close: %w", err)
+		}
+	default:
+		gw := gzip.NewWriter(&buf)
+		tw := tar.NewWriter(gw)
+		for _, file := range files {
+			err := tw.WriteHeader(&tar.Header{
+				Typeflag: tar.TypeReg,
+				Name:     file.name,
+				Mode:     0o644,
+				Size:     int64(len(file.content)),
+				ModTime:  modTime,
+				Format:   tar.FormatPAX,
+			})
+			if err != nil {
+				return nil, fmt.Errorf("tw.WriteHeader: %w", err)
+			}
+
+			_, err = io.WriteString(tw, file.content)
+			if err != nil {
+				return nil, fmt.Errorf("io.WriteString: %w", err)
+			}
+		}
+
+		err := tw.Close()
+		if err != nil {
+			return nil, fmt.Errorf("tw.Close: %w", err)
+		}
+
+		err = gw.Close()
+		if err != nil {
+			return nil, fmt.Errorf("gw.Close: %w", err)
+		}
+	}
+
+	return buf.Bytes(), nil
+}
+
+func archiveContentType(format string) string {
+	if format == archiveZip {
+		return "application/zip"
+	}
+
+	return "application/gzip"
+}
+
+// writeError writes the error in the format of the gateway errors.
+func writeError(w http.ResponseWriter, err error) {
+	// Errors of the gRPC server are already converted.
+	st, ok := status.FromError(err)
+	if !ok {
+		st = apiError(err)
+	}
+
+	body, marshalErr := protojson.Marshal(st.Proto())
+	if marshalErr != nil {
+		body = []byte(`{"code":13,"message":"failed to marshal error"}`)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(runtime.HTTPStatusFromCode(st.Code()))
+	_, _ = w.Write(body)
+}
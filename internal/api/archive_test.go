@@ -0,0 +1,110 @@
+package api
+
+import (
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/descriptorpb"
+
+	"github.com/easyp-tech/service/internal/core"
+)
+
+func TestInsert(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		content   string
+		point     string
+		insertion string
+		want      string
+		wantErr   bool
+	}{
+		"top level": {
+			content:   "a\n// @@protoc_insertion_point(imports)\nb\n",
+			point:     "imports",
+			insertion: "import x\n",
+			want:      "a\nimport x\n// @@protoc_insertion_point(imports)\nb\n",
+		},
+		"indented": {
+			content:   "class A {\n\t  // @@protoc_insertion_point(class_scope:A)\n}\n",
+			point:     "class_scope:A",
+			insertion: "int x;\n\nint y;\n",
+			want:      "class A {\n\t  int x;\n\n\t  int y;\n\t  // @@protoc_insertion_point(class_scope:A)\n}\n",
+		},
+		"without newline": {
+			content:   "  // @@protoc_insertion_point(x)\n",
+			point:     "x",
+			insertion: "y",
+			want:      "  y\n  // @@protoc_insertion_point(x)\n",
+		},
+		"empty insertion": {
+			content: "// @@protoc_insertion_point(x)\n",
+			point:   "x",
+			want:    "// @@protoc_insertion_point(x)\n",
+		},
+		"missing point": {
+			content:   "// @@protoc_insertion_point(other)\n",
+			point:     "x",
+			insertion: "y\n",
+			wantErr:   true,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := insert(tt.content, tt.point, tt.insertion)
+			if tt.wantErr {
+				require.ErrorIs(t, err, errInsertionPoint)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, tt.want, got)
+			}
+		})
+	}
+}
+
+func TestWriteArchiveChecksumsConflict(t *testing.T) {
+	t.Parallel()
+
+	_, err := writeArchive(archiveTarGz, []outputFile{{name: checksumsFile, content: "x"}})
+	require.ErrorIs(t, err, core.ErrGenerationFailed)
+
+	_, err = writeArchive(archiveZip, []outputFile{{name: "dir/" + checksumsFile, content: "x"}})
+	require.NoError(t, err)
+}
+
+func TestArchiveRequest(t *testing.T) {
+	t.Parallel()
+
+	set := &descriptorpb.FileDescriptorSet{File: []*descriptorpb.FileDescriptorProto{{}}}
+
+	query := url.Values{
+		"plugin":             {"protobuf/go:v1.36.10"},
+		"module":             {"github.com/googleapis/googleapis@v0.0.1"},
+		"verify_determinism": {"true"},
+		"header":             {"// header"},
+		"include":            {"**/*.go"},
+		"go_format":          {"false"},
+	}
+
+	req, err := archiveRequest(query, set, []string{"a.proto"})
+	require.NoError(t, err)
+	require.Equal(t, "protobuf/go:v1.36.10", req.PluginName)
+	require.Equal(t, []string{"github.com/googleapis/googleapis@v0.0.1"}, req.Modules)
+	require.True(t, req.VerifyDeterminism)
+	require.Equal(t, "// header", req.PostProcess.GetHeader())
+	require.Equal(t, []string{"**/*.go"}, req.PostProcess.Include)
+	require.False(t, req.PostProcess.GetGoFormat())
+	require.NotNil(t, req.PostProcess.GoFormat)
+	require.Nil(t, req.PostProcess.PathPrefix)
+
+	req, err = archiveRequest(url.Values{"plugin": {"protobuf/go:v1.36.10"}}, set, nil)
+	require.NoError(t, err)
+	require.Nil(t, req.PostProcess)
+
+	_, err = archiveRequest(url.Values{"verify_determinism": {"maybe"}}, set, nil)
+	require.ErrorIs(t, err, core.ErrInvalidArgument)
+}
@@ -0,0 +1,132 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"math/rand/v2"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/sipki-tech/dev-platform/logger"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/easyp-tech/service/api/generator/v1"
+)
+
+var errInvalidSampleRate = errors.New("sample rate must be from 0 to 1")
+
+// Recorder writes sampled GenerateCode calls to a file, one GenerateCodeRecord per line in the JSON form.
+type Recorder struct {
+	mu   sync.Mutex
+	file *os.File
+	// rate is the share of the recorded calls, from 0 to 1.
+	rate float64
+}
+
+// NewRecorder opens the file for appending, the recorder is disabled when the file is empty.
+func NewRecorder(file string, rate float64) (*Recorder, error) {
+	if !(rate >= 0 && rate <= 1) {
+		return nil, fmt.Errorf("%w: %v", errInvalidSampleRate, rate)
+	}
+
+	if file == "" {
+		return nil, nil //nolint:nilnil // A nil recorder records nothing.
+	}
+
+	f, err := os.OpenFile(file, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0o600) //nolint:gosec // The file is configured.
+	if err != nil {
+		return nil, fmt.Errorf("os.OpenFile: %w", err)
+	}
+
+	return &Recorder{file: f, rate: rate}, nil
+}
+
+// Close closes the file.
+func (r *Recorder) Close() error {
+	if r == nil {
+		return nil
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.file.Close()
+}
+
+// interceptor records sampled GenerateCode calls.
+func (r *Recorder) interceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		if info.FullMethod != generator.ServiceAPI_GenerateCode_FullMethodName || rand.Float64() >= r.rate { //nolint:gosec // Sampling doesn't need a secure source.
+			return handler(ctx, req)
+		}
+
+		start := time.Now()
+		resp, err := handler(ctx, req)
+		duration := time.Since(start)
+
+		recordErr := r.record(req, resp, err, start, duration)
+		if recordErr != nil {
+			logger.FromContext(ctx).Error("record generate code", slog.String(logger.Error.String(), recordErr.Error()))
+		}
+
+		return resp, err
+	}
+}
+
+func (r *Recorder) record(req, resp any, callErr error, start time.Time, duration time.Duration) error {
+	request, _ := req.(*generator.GenerateCodeRequest)
+	record := &generator.GenerateCodeRecord{
+		PluginName: request.GetPluginName(),
+		StartedAt:  timestamppb.New(start),
+		Duration:   durationpb.New(duration),
+	}
+
+	var err error
+	record.Request, err = proto.Marshal(request)
+	if err != nil {
+		return fmt.Errorf("proto.Marshal: %w", err)
+	}
+
+	if callErr != nil {
+		st := callStatus(callErr)
+		record.Code, record.Error = uint32(st.Code()), st.Message()
+	} else if response, ok := resp.(*generator.GenerateCodeResponse); ok {
+		record.Response, err = proto.Marshal(response)
+		if err != nil {
+			return fmt.Errorf("proto.Marshal: %w", err)
+		}
+	}
+
+	line, err := protojson.Marshal(record)
+	if err != nil {
+		return fmt.Errorf("protojson.Marshal: %w", err)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	_, err = r.file.Write(append(line, '\n'))
+	if err != nil {
+		return fmt.Errorf("r.file.Write: %w", err)
+	}
+
+	return nil
+}
+
+// callStatus returns the status of the error, core errors are mapped like the API maps them.
+func callStatus(err error) *status.Status {
+	st, ok := status.FromError(err)
+	if ok {
+		return st
+	}
+
+	return apiError(err)
+}
@@ -0,0 +1,58 @@
+package api
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/easyp-tech/service/api/web/v1"
+)
+
+// redactHandler hides secret values of the logged requests, the gRPC server logs every payload.
+type redactHandler struct {
+	slog.Handler
+}
+
+// Handle implements slog.Handler.
+func (h redactHandler) Handle(ctx context.Context, r slog.Record) error {
+	redacted := slog.NewRecord(r.Time, r.Level, r.Message, r.PC)
+	r.Attrs(func(a slog.Attr) bool {
+		redacted.AddAttrs(redactAttr(a))
+		return true
+	})
+
+	return h.Handler.Handle(ctx, redacted)
+}
+
+// WithAttrs implements slog.Handler.
+func (h redactHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	redacted := make([]slog.Attr, len(attrs))
+	for i, a := range attrs {
+		redacted[i] = redactAttr(a)
+	}
+
+	return redactHandler{h.Handler.WithAttrs(redacted)}
+}
+
+// WithGroup implements slog.Handler.
+func (h redactHandler) WithGroup(name string) slog.Handler {
+	return redactHandler{h.Handler.WithGroup(name)}
+}
+
+func redactAttr(a slog.Attr) slog.Attr {
+	switch a.Value.Kind() {
+	case slog.KindGroup:
+		group := a.Value.Group()
+		redacted := make([]any, len(group))
+		for i, attr := range group {
+			redacted[i] = redactAttr(attr)
+		}
+
+		return slog.Group(a.Key, redacted...)
+	case slog.KindAny:
+		if req, ok := a.Value.Any().(*web.PutSecretRequest); ok {
+			return slog.Group(a.Key, slog.String("name", req.GetName()), slog.String("value", "[REDACTED]"))
+		}
+	}
+
+	return a
+}
@@ -0,0 +1,27 @@
+package api
+
+import (
+	"bytes"
+	"log/slog"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/easyp-tech/service/api/web/v1"
+)
+
+func TestRedactHandler(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+	log := slog.New(redactHandler{slog.NewJSONHandler(&buf, nil)})
+
+	req := &web.PutSecretRequest{Name: "TOKEN", Value: "secret-value"}
+	log.Info("request", "grpc.request.content", req)
+	log.With("request", req).Info("with")
+	log.Info("group", slog.Group("grpc", slog.Any("content", req)))
+
+	require.NotContains(t, buf.String(), "secret-value")
+	require.Contains(t, buf.String(), "TOKEN")
+	require.Contains(t, buf.String(), "[REDACTED]")
+}
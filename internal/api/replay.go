@@ -0,0 +1,166 @@
+package api
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/easyp-tech/service/api/generator/v1"
+	"github.com/easyp-tech/service/internal/core"
+)
+
+type (
+	// GenerateFunc runs GenerateCode, on a service instance or on the core directly.
+	GenerateFunc func(ctx context.Context, req *generator.GenerateCodeRequest) (*generator.GenerateCodeResponse, error)
+
+	// ReplayReport compares replayed GenerateCode calls with the recorded ones.
+	ReplayReport struct {
+		Results    []ReplayResult
+		Matched    int
+		Mismatched int
+		// Recorded and Replayed are latencies of the recorded and the replayed calls.
+		Recorded Latency
+		Replayed Latency
+	}
+
+	// ReplayResult is a replayed call.
+	ReplayResult struct {
+		// Line is the 1-based line of the record.
+		Line     int
+		Plugin   string
+		Recorded time.Duration
+		Replayed time.Duration
+		Matched  bool
+		// Mismatch is the difference of the errors, the plugin errors or the supported features.
+		Mismatch string
+		// Files are the differences from the recorded files to the replayed ones.
+		Files []core.FileDiff
+	}
+
+	// Latency are percentiles of call durations.
+	Latency struct {
+		P50 time.Duration
+		P95 time.Duration
+		Max time.Duration
+	}
+)
+
+// maxReplayResponseSize limits responses of a service instance, generated code exceeds the default 4 MiB.
+const maxReplayResponseSize = 256 << 20
+
+// CoreGenerate returns a GenerateFunc running the calls on the core.
+func CoreGenerate(app *core.Core) GenerateFunc {
+	return (&API{app: app}).GenerateCode
+}
+
+// ClientGenerate returns a GenerateFunc sending the calls to a service instance.
+func ClientGenerate(conn grpc.ClientConnInterface) GenerateFunc {
+	client := generator.NewServiceAPIClient(conn)
+
+	return func(ctx context.Context, req *generator.GenerateCodeRequest) (*generator.GenerateCodeResponse, error) {
+		return client.GenerateCode(ctx, req, grpc.MaxCallRecvMsgSize(maxReplayResponseSize))
+	}
+}
+
+// Replay runs the recorded calls one by one and compares them with the recorded responses.
+func Replay(ctx context.Context, records io.Reader, generate GenerateFunc) (*ReplayReport, error) {
+	report := &ReplayReport{}
+	var recorded, replayed []time.Duration
+
+	reader := bufio.NewReader(records)
+	for line := 1; ; line++ {
+		data, err := reader.ReadBytes('\n')
+		switch {
+		case errors.Is(err, io.EOF) && len(data) == 0:
+			report.Recorded, report.Replayed = latency(recorded), latency(replayed)
+
+			return report, nil
+		case err != nil && !errors.Is(err, io.EOF):
+			return nil, fmt.Errorf("reader.ReadBytes: %w", err)
+		case len(bytes.TrimSpace(data)) == 0:
+			continue
+		}
+
+		result, err := replay(ctx, data, generate)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+
+		result.Line = line
+		if result.Matched {
+			report.Matched++
+		} else {
+			report.Mismatched++
+		}
+
+		recorded, replayed = append(recorded, result.Recorded), append(replayed, result.Replayed)
+		report.Results = append(report.Results, *result)
+	}
+}
+
+func replay(ctx context.Context, data []byte, generate GenerateFunc) (*ReplayResult, error) {
+	record := &generator.GenerateCodeRecord{}
+	err := protojson.Unmarshal(data, record)
+	if err != nil {
+		return nil, fmt.Errorf("protojson.Unmarshal: %w", err)
+	}
+
+	req := &generator.GenerateCodeRequest{}
+	err = proto.Unmarshal(record.Request, req)
+	if err != nil {
+		return nil, fmt.Errorf("proto.Unmarshal: %w", err)
+	}
+
+	result := &ReplayResult{Plugin: record.PluginName, Recorded: record.Duration.AsDuration()}
+
+	start := time.Now()
+	resp, err := generate(ctx, req)
+	result.Replayed = time.Since(start)
+
+	code, message := codes.OK, ""
+	if err != nil {
+		st := callStatus(err)
+		code, message = st.Code(), st.Message()
+	}
+
+	switch {
+	case codes.Code(record.Code) != code:
+		result.Mismatch = fmt.Sprintf("status: recorded %s %q, replayed %s %q", codes.Code(record.Code), record.Error, code, message)
+	case code == codes.OK:
+		recordedResp := &generator.GenerateCodeResponse{}
+		err = proto.Unmarshal(record.Response, recordedResp)
+		if err != nil {
+			return nil, fmt.Errorf("proto.Unmarshal: %w", err)
+		}
+
+		result.Mismatch, result.Files = core.CompareResponses(recordedResp.GetCodeGeneratorResponse(), resp.GetCodeGeneratorResponse())
+	}
+
+	result.Matched = result.Mismatch == "" && len(result.Files) == 0
+
+	return result, nil
+}
+
+// latency returns the percentiles of the durations, the nearest-rank ones.
+func latency(durations []time.Duration) Latency {
+	if len(durations) == 0 {
+		return Latency{}
+	}
+
+	sorted := slices.Sorted(slices.Values(durations))
+	rank := func(p int) time.Duration {
+		return sorted[(len(sorted)*p+99)/100-1]
+	}
+
+	return Latency{P50: rank(50), P95: rank(95), Max: sorted[len(sorted)-1]}
+}
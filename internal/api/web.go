@@ -0,0 +1,309 @@
+package api
+
+import (
+	"context"
+	"fmt"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/easyp-tech/service/api/web/v1"
+	"github.com/easyp-tech/service/internal/core"
+)
+
+var _ web.ServiceAPIServer = (*webAPI)(nil)
+
+// webAPI provides the management API of the plugin registry.
+type webAPI struct {
+	app *core.Core
+}
+
+// Plugins implements web.ServiceAPIServer.
+func (api *webAPI) Plugins(ctx context.Context, _ *web.PluginsRequest) (*web.PluginsResponse, error) {
+	plugins, err := api.app.Plugins(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("api.app.Plugins: %w", err)
+	}
+
+	return &web.PluginsResponse{
+		Plugins: pluginInfos(plugins),
+	}, nil
+}
+
+// RegisterPlugin implements web.ServiceAPIServer.
+func (api *webAPI) RegisterPlugin(ctx context.Context, request *web.RegisterPluginRequest) (*web.RegisterPluginResponse, error) {
+	var config []byte
+	if request.Config != nil {
+		var err error
+		config, err = protojson.Marshal(request.Config)
+		if err != nil {
+			return nil, fmt.Errorf("protojson.Marshal: %w", err)
+		}
+	}
+
+	info, err := api.app.RegisterPlugin(ctx, core.RegisterPluginRequest{
+		Group:    request.Group,
+		Name:     request.Name,
+		Version:  request.Version,
+		Config:   config,
+		Image:    request.Image,
+		Registry: request.Registry,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("api.app.RegisterPlugin: %w", err)
+	}
+
+	resp := &web.RegisterPluginResponse{
+		Plugin: pluginInfo(info),
+	}
+
+	if request.RunConformance {
+		report, err := api.app.Conformance(ctx, info.Group+"/"+info.Name+":"+info.Version, false)
+		if err != nil {
+			resp.ConformanceError = err.Error()
+		} else {
+			resp.Conformance = conformanceReport(report)
+		}
+	}
+
+	return resp, nil
+}
+
+// RunConformance implements web.ServiceAPIServer.
+func (api *webAPI) RunConformance(ctx context.Context, request *web.RunConformanceRequest) (*web.RunConformanceResponse, error) {
+	report, err := api.app.Conformance(ctx, request.Plugin, false)
+	if err != nil {
+		return nil, fmt.Errorf("api.app.Conformance: %w", err)
+	}
+
+	return &web.RunConformanceResponse{
+		Report: conformanceReport(report),
+	}, nil
+}
+
+// Secrets implements web.ServiceAPIServer.
+func (api *webAPI) Secrets(ctx context.Context, _ *web.SecretsRequest) (*web.SecretsResponse, error) {
+	secrets, err := api.app.Secrets(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("api.app.Secrets: %w", err)
+	}
+
+	resp := &web.SecretsResponse{
+		Secrets: make([]*web.SecretInfo, len(secrets)),
+	}
+	for i := range secrets {
+		resp.Secrets[i] = secretInfo(&secrets[i])
+	}
+
+	return resp, nil
+}
+
+// PutSecret implements web.ServiceAPIServer.
+func (api *webAPI) PutSecret(ctx context.Context, request *web.PutSecretRequest) (*web.PutSecretResponse, error) {
+	info, err := api.app.PutSecret(ctx, request.Name, request.Value)
+	if err != nil {
+		return nil, fmt.Errorf("api.app.PutSecret: %w", err)
+	}
+
+	return &web.PutSecretResponse{
+		Secret: secretInfo(info),
+	}, nil
+}
+
+// DeleteSecret implements web.ServiceAPIServer.
+func (api *webAPI) DeleteSecret(ctx context.Context, request *web.DeleteSecretRequest) (*web.DeleteSecretResponse, error) {
+	err := api.app.DeleteSecret(ctx, request.Name)
+	if err != nil {
+		return nil, fmt.Errorf("api.app.DeleteSecret: %w", err)
+	}
+
+	return &web.DeleteSecretResponse{}, nil
+}
+
+// AddSignature implements web.ServiceAPIServer.
+func (api *webAPI) AddSignature(ctx context.Context, request *web.AddSignatureRequest) (*web.AddSignatureResponse, error) {
+	err := api.app.AddSignature(ctx, request.Digest, request.Payload, request.Signature)
+	if err != nil {
+		return nil, fmt.Errorf("api.app.AddSignature: %w", err)
+	}
+
+	return &web.AddSignatureResponse{}, nil
+}
+
+// Modules implements web.ServiceAPIServer.
+func (api *webAPI) Modules(ctx context.Context, _ *web.ModulesRequest) (*web.ModulesResponse, error) {
+	modules, err := api.app.Modules(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("api.app.Modules: %w", err)
+	}
+
+	resp := &web.ModulesResponse{
+		Modules: make([]*web.ModuleInfo, len(modules)),
+	}
+	for i := range modules {
+		resp.Modules[i] = moduleInfo(&modules[i])
+	}
+
+	return resp, nil
+}
+
+// PutModule implements web.ServiceAPIServer.
+func (api *webAPI) PutModule(ctx context.Context, request *web.PutModuleRequest) (*web.PutModuleResponse, error) {
+	info, err := api.app.PutModule(ctx, request.Name, request.Version, request.DescriptorSet.GetFile())
+	if err != nil {
+		return nil, fmt.Errorf("api.app.PutModule: %w", err)
+	}
+
+	return &web.PutModuleResponse{
+		Module: moduleInfo(info),
+	}, nil
+}
+
+// SyncCatalog implements web.ServiceAPIServer.
+func (api *webAPI) SyncCatalog(ctx context.Context, _ *web.SyncCatalogRequest) (*web.SyncCatalogResponse, error) {
+	report, err := api.app.SyncCatalog(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("api.app.SyncCatalog: %w", err)
+	}
+
+	resp := &web.SyncCatalogResponse{
+		Created:         pluginInfos(report.Created),
+		Updated:         pluginInfos(report.Updated),
+		OrphanedPlugins: pluginInfos(report.OrphanedPlugins),
+		OrphanedImages:  make([]*web.OrphanedImage, len(report.OrphanedImages)),
+	}
+	for i, image := range report.OrphanedImages {
+		resp.OrphanedImages[i] = &web.OrphanedImage{Image: image.Image, Reason: image.Reason}
+	}
+
+	return resp, nil
+}
+
+func moduleInfo(info *core.ModuleInfo) *web.ModuleInfo {
+	return &web.ModuleInfo{
+		Name:      info.Name,
+		Version:   info.Version,
+		Files:     int32(info.Files), //nolint:gosec // Files of a module within the message size.
+		CreatedAt: timestamppb.New(info.CreatedAt),
+	}
+}
+
+func secretInfo(info *core.SecretInfo) *web.SecretInfo {
+	return &web.SecretInfo{
+		Name:      info.Name,
+		CreatedAt: timestamppb.New(info.CreatedAt),
+		UpdatedAt: timestamppb.New(info.UpdatedAt),
+	}
+}
+
+func pluginInfos(plugins []core.PluginInfo) []*web.PluginInfo {
+	infos := make([]*web.PluginInfo, len(plugins))
+	for i := range plugins {
+		infos[i] = pluginInfo(&plugins[i])
+	}
+
+	return infos
+}
+
+func pluginInfo(info *core.PluginInfo) *web.PluginInfo {
+	return &web.PluginInfo{
+		Id:          info.ID.String(),
+		Group:       info.Group,
+		Name:        info.Name,
+		Version:     info.Version,
+		CreatedAt:   timestamppb.New(info.CreatedAt),
+		ImageStatus: info.ImageStatus,
+		ImageError:  info.ImageError,
+		Image:       info.Image,
+		Registry:    info.Registry,
+		Description: info.Manifest.Description,
+		Source:      info.Manifest.Source,
+		License:     info.Manifest.License,
+		Options:     pluginOptions(info.Manifest.Options),
+		Compatibility: &web.PluginCompatibility{
+			CompilerVersion: info.Compatibility.CompilerVersion,
+			Plugins:         info.Compatibility.Plugins,
+			Mode:            info.Compatibility.Mode,
+		},
+		PostProcess: &web.PluginPostProcess{
+			Header:     pointerValue(info.PostProcess.Header),
+			PathPrefix: pointerValue(info.PostProcess.PathPrefix),
+			Include:    info.PostProcess.Include,
+			Exclude:    info.PostProcess.Exclude,
+			GoFormat:   pointerValue(info.PostProcess.GoFormat),
+		},
+		DeterminismBadge: determinismBadge(info.Determinism),
+		Determinism:      pluginDeterminism(info.Determinism),
+	}
+}
+
+func determinismBadge(check *core.DeterminismCheck) string {
+	if check == nil {
+		return ""
+	}
+
+	return check.Badge()
+}
+
+func pluginDeterminism(check *core.DeterminismCheck) *web.PluginDeterminism {
+	if check == nil {
+		return nil
+	}
+
+	return &web.PluginDeterminism{
+		Differences: check.Differences,
+		Error:       check.Error,
+		Source:      check.Source,
+		CheckedAt:   timestamppb.New(check.CheckedAt),
+	}
+}
+
+func conformanceReport(report *core.ConformanceReport) *web.ConformanceReport {
+	cases := make([]*web.ConformanceCase, len(report.Cases))
+	for i, result := range report.Cases {
+		files := make([]*web.ConformanceFileDiff, len(result.Files))
+		for j, file := range result.Files {
+			files[j] = &web.ConformanceFileDiff{Name: file.Name, Status: file.Status, Diff: file.Diff}
+		}
+
+		cases[i] = &web.ConformanceCase{
+			Name:   result.Name,
+			Passed: result.Passed,
+			Error:  result.Error,
+			Files:  files,
+		}
+	}
+
+	//nolint:gosec // Counts of fixtures fit int32.
+	return &web.ConformanceReport{
+		Plugin: report.Plugin,
+		Cases:  cases,
+		Passed: int32(report.Passed),
+		Failed: int32(report.Failed),
+	}
+}
+
+func pointerValue[T any](v *T) T {
+	if v == nil {
+		var zero T
+
+		return zero
+	}
+
+	return *v
+}
+
+func pluginOptions(options []core.PluginOption) []*web.PluginOption {
+	infos := make([]*web.PluginOption, len(options))
+	for i, option := range options {
+		infos[i] = &web.PluginOption{
+			Name:        option.Name,
+			Description: option.Description,
+			Type:        option.Type,
+			Values:      option.Values,
+			Default:     option.Default,
+		}
+	}
+
+	return infos
+}
@@ -0,0 +1,269 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+
+	"google.golang.org/protobuf/types/pluginpb"
+)
+
+// Compatibility modes.
+const (
+	// CompatibilityWarn returns violations of the constraints as warnings of the generation.
+	CompatibilityWarn = "warn"
+	// CompatibilityFail rejects the generation with ErrIncompatiblePlugin.
+	CompatibilityFail = "fail"
+)
+
+var errInvalidConstraint = errors.New("invalid compatibility constraint")
+
+type (
+	// versionRange is a set of alternatives, each of them a set of comparisons which all must hold.
+	versionRange [][]comparison
+
+	comparison struct {
+		op      string
+		version semver
+	}
+
+	// semver is a version with an optional "v" prefix, missing minor and patch numbers are zeros.
+	semver struct {
+		major, minor, patch int
+		prerelease          string
+	}
+)
+
+// Check validates the constraints.
+func (c Compatibility) Check() error {
+	switch c.Mode {
+	case "", CompatibilityWarn, CompatibilityFail:
+	default:
+		return fmt.Errorf("%w: unknown mode %q", errInvalidConstraint, c.Mode)
+	}
+
+	if c.CompilerVersion != "" {
+		_, err := parseRange(c.CompilerVersion)
+		if err != nil {
+			return fmt.Errorf("compiler_version: %w", err)
+		}
+	}
+
+	for _, plugin := range slices.Sorted(maps.Keys(c.Plugins)) {
+		group, name, ok := strings.Cut(plugin, "/")
+		if !ok || !pluginNamePattern.MatchString(group) || !pluginNamePattern.MatchString(name) {
+			return fmt.Errorf("%w: plugin %q must be <group>/<name>", errInvalidConstraint, plugin)
+		}
+
+		_, err := parseRange(c.Plugins[plugin])
+		if err != nil {
+			return fmt.Errorf("plugin %s: %w", plugin, err)
+		}
+	}
+
+	return nil
+}
+
+// violations returns the constraints which the compiler and the plugins generated together don't satisfy,
+// and the plugins which aren't checked because their versions aren't semver, e.g. "latest".
+func (c Compatibility) violations(compiler *pluginpb.Version, plugins []PluginInfo) (problems, unchecked []string) {
+
+	if c.CompilerVersion != "" && compiler != nil {
+		version := fmt.Sprintf("%d.%d.%d", compiler.GetMajor(), compiler.GetMinor(), compiler.GetPatch())
+		if compiler.GetSuffix() != "" {
+			version += "-" + compiler.GetSuffix()
+		}
+
+		ok, err := matchVersion(c.CompilerVersion, version)
+		switch {
+		case err != nil:
+			problems = append(problems, fmt.Sprintf("compiler %s: %s", version, err))
+		case !ok:
+			problems = append(problems, fmt.Sprintf("compiler %s is outside of %q", version, c.CompilerVersion))
+		}
+	}
+
+	for _, plugin := range plugins {
+		key := plugin.Group + "/" + plugin.Name
+		constraint, ok := c.Plugins[key]
+		if !ok {
+			continue
+		}
+
+		ok, err := matchVersion(constraint, plugin.Version)
+		switch {
+		case err != nil:
+			unchecked = append(unchecked, fmt.Sprintf("plugin %s:%s is not checked against %q: %s", key, plugin.Version, constraint, err))
+		case !ok:
+			problems = append(problems, fmt.Sprintf("plugin %s:%s is outside of %q", key, plugin.Version, constraint))
+		}
+	}
+
+	return problems, unchecked
+}
+
+// checkCompatibility returns the violated constraints of the plugin and the unchecked plugins as warnings,
+// or fails with ErrIncompatiblePlugin on violations in the CompatibilityFail mode.
+func checkCompatibility(info *PluginInfo, req *pluginpb.CodeGeneratorRequest, plugins []PluginInfo) ([]string, error) {
+	problems, unchecked := info.Compatibility.violations(req.GetCompilerVersion(), plugins)
+	if len(problems) > 0 && info.Compatibility.Mode == CompatibilityFail {
+		return nil, fmt.Errorf("%w: %s/%s:%s: %s", ErrIncompatiblePlugin, info.Group, info.Name, info.Version, strings.Join(problems, "; "))
+	}
+
+	return slices.Concat(problems, unchecked), nil
+}
+
+// resolvePlugins returns the plugins generated together with the requested one. Registered plugins
+// are resolved by the registry, the others may run elsewhere and keep the requested version.
+func (c *Core) resolvePlugins(ctx context.Context, names []string) ([]PluginInfo, error) {
+	plugins := make([]PluginInfo, len(names))
+	for i, pluginName := range names {
+		group, err := getGroup(pluginName)
+		if err != nil {
+			return nil, fmt.Errorf("getGroup: %w", err)
+		}
+
+		name, version, err := getNameAndVersion(pluginName)
+		if err != nil {
+			return nil, fmt.Errorf("getNameAndVersion: %w", err)
+		}
+
+		plugin, err := c.registry.Get(ctx, group, name, version)
+		switch {
+		case errors.Is(err, ErrNotFound):
+			plugins[i] = PluginInfo{Group: group, Name: name, Version: version}
+		case err != nil:
+			return nil, fmt.Errorf("c.registry.Get: %w", err)
+		default:
+			plugins[i] = *plugin.Info(ctx)
+		}
+	}
+
+	return plugins, nil
+}
+
+// matchVersion reports whether the version is in the range.
+func matchVersion(constraint, version string) (bool, error) {
+	r, err := parseRange(constraint)
+	if err != nil {
+		return false, err
+	}
+
+	v, err := parseSemver(version)
+	if err != nil {
+		return false, err
+	}
+
+	return r.contains(v), nil
+}
+
+// parseRange parses space separated comparisons, e.g. ">=v1.36.0 <v2", alternatives are separated by "||".
+// Supported operators are =, !=, >, >=, < and <=, a version without an operator is an exact match.
+func parseRange(s string) (versionRange, error) {
+	var r versionRange
+	for alternative := range strings.SplitSeq(s, "||") {
+		fields := strings.Fields(alternative)
+		if len(fields) == 0 {
+			return nil, fmt.Errorf("%w: %q has an empty alternative", errInvalidConstraint, s)
+		}
+
+		comparisons := make([]comparison, len(fields))
+		for i, field := range fields {
+			version := strings.TrimLeft(field, "<>=!")
+			op := field[:len(field)-len(version)]
+			switch op {
+			case "":
+				op = "="
+			case "=", "!=", ">", ">=", "<", "<=":
+			default:
+				return nil, fmt.Errorf("%w: %q: unknown operator %q", errInvalidConstraint, s, op)
+			}
+
+			v, err := parseSemver(version)
+			if err != nil {
+				return nil, fmt.Errorf("%w: %q: %w", errInvalidConstraint, s, err)
+			}
+
+			comparisons[i] = comparison{op: op, version: v}
+		}
+
+		r = append(r, comparisons)
+	}
+
+	return r, nil
+}
+
+func (r versionRange) contains(v semver) bool {
+	return slices.ContainsFunc(r, func(comparisons []comparison) bool {
+		for _, c := range comparisons {
+			n := v.compare(c.version)
+			ok := false
+			switch c.op {
+			case "=":
+				ok = n == 0
+			case "!=":
+				ok = n != 0
+			case ">":
+				ok = n > 0
+			case ">=":
+				ok = n >= 0
+			case "<":
+				ok = n < 0
+			case "<=":
+				ok = n <= 0
+			}
+
+			if !ok {
+				return false
+			}
+		}
+
+		return true
+	})
+}
+
+func parseSemver(s string) (semver, error) {
+	version, _, _ := strings.Cut(strings.TrimPrefix(s, "v"), "+")
+	version, prerelease, _ := strings.Cut(version, "-")
+
+	parts := strings.Split(version, ".")
+	if len(parts) > 3 {
+		return semver{}, fmt.Errorf("version %q is not semver", s)
+	}
+
+	var numbers [3]int
+	for i, part := range parts {
+		n, err := strconv.Atoi(part)
+		if err != nil || n < 0 {
+			return semver{}, fmt.Errorf("version %q is not semver", s)
+		}
+
+		numbers[i] = n
+	}
+
+	return semver{major: numbers[0], minor: numbers[1], patch: numbers[2], prerelease: prerelease}, nil
+}
+
+// compare orders the versions, a prerelease precedes the release.
+func (v semver) compare(other semver) int {
+	switch {
+	case v.major != other.major:
+		return v.major - other.major
+	case v.minor != other.minor:
+		return v.minor - other.minor
+	case v.patch != other.patch:
+		return v.patch - other.patch
+	case v.prerelease == other.prerelease:
+		return 0
+	case v.prerelease == "":
+		return 1
+	case other.prerelease == "":
+		return -1
+	default:
+		return strings.Compare(v.prerelease, other.prerelease)
+	}
+}
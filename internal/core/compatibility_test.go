@@ -0,0 +1,89 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMatchVersion(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		constraint string
+		version    string
+		want       bool
+		wantErr    bool
+	}{
+		"exact":                  {constraint: "3.21.0", version: "v3.21.0", want: true},
+		"exact with v":           {constraint: "v3.21.0", version: "v3.21.0", want: true},
+		"exact mismatch":         {constraint: "3.21.0", version: "v3.21.1"},
+		"equal":                  {constraint: "=v1.2", version: "v1.2.0", want: true},
+		"not equal":              {constraint: "!=v1.2.0", version: "v1.2.0"},
+		"greater":                {constraint: ">1", version: "v1.0.1", want: true},
+		"greater or equal":       {constraint: ">=v1.36.0", version: "v1.36.0", want: true},
+		"less":                   {constraint: "<v2", version: "v2.0.0"},
+		"less or equal":          {constraint: "<=v2", version: "v2.0.0", want: true},
+		"range":                  {constraint: ">=v1.36.0 <v2", version: "v1.40.0", want: true},
+		"range mismatch":         {constraint: ">=v1.36.0 <v2", version: "v2.1.0"},
+		"alternatives":           {constraint: "<v1 || >=v3", version: "v3.0.0", want: true},
+		"unknown operator":       {constraint: "=>v1", version: "v1.0.0", wantErr: true},
+		"empty alternative":      {constraint: "v1 ||", version: "v1.0.0", wantErr: true},
+		"operator only":          {constraint: ">=", version: "v1.0.0", wantErr: true},
+		"invalid version":        {constraint: ">=vx", version: "v1.0.0", wantErr: true},
+		"invalid plugin version": {constraint: ">=v1", version: "latest", wantErr: true},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := matchVersion(tt.constraint, tt.version)
+			if tt.wantErr {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, tt.want, got)
+			}
+		})
+	}
+}
+
+func TestCheckCompatibility(t *testing.T) {
+	t.Parallel()
+
+	info := &PluginInfo{
+		Group:   "grpc",
+		Name:    "go",
+		Version: "v1.5.1",
+		Compatibility: Compatibility{
+			Plugins: map[string]string{"protobuf/go": ">=v1.36.0 <v2"},
+			Mode:    CompatibilityFail,
+		},
+	}
+
+	tests := map[string]struct {
+		plugins  []PluginInfo
+		warnings int
+		wantErr  bool
+	}{
+		"satisfied":     {plugins: []PluginInfo{{Group: "protobuf", Name: "go", Version: "v1.36.10"}}},
+		"violated":      {plugins: []PluginInfo{{Group: "protobuf", Name: "go", Version: "v1.35.0"}}, wantErr: true},
+		"not semver":    {plugins: []PluginInfo{{Group: "protobuf", Name: "go", Version: "latest"}}, warnings: 1},
+		"unconstrained": {plugins: []PluginInfo{{Group: "protobuf", Name: "java", Version: "latest"}}},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			warnings, err := checkCompatibility(info, nil, tt.plugins)
+			if tt.wantErr {
+				require.ErrorIs(t, err, ErrIncompatiblePlugin)
+			} else {
+				require.NoError(t, err)
+				require.Len(t, warnings, tt.warnings)
+			}
+		})
+	}
+}
@@ -0,0 +1,133 @@
+package core
+
+import (
+	"context"
+	"fmt"
+
+	"google.golang.org/protobuf/types/pluginpb"
+)
+
+// Conformance runs the fixtures of the plugin and compares the responses with the expected ones.
+// With update, expected responses which differ are replaced by the generated ones.
+func (c *Core) Conformance(ctx context.Context, pluginName string, update bool) (*ConformanceReport, error) {
+	group, err := getGroup(pluginName)
+	if err != nil {
+		return nil, fmt.Errorf("getGroup: %w", err)
+	}
+
+	name, version, err := getNameAndVersion(pluginName)
+	if err != nil {
+		return nil, fmt.Errorf("getNameAndVersion: %w", err)
+	}
+
+	// The name is a path of the fixtures.
+	if !ValidPluginName(group, name, version) {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidPluginName, pluginName)
+	}
+
+	fixtures, err := c.fixtures.Fixtures(ctx, group, name, version)
+	if err != nil {
+		return nil, fmt.Errorf("c.fixtures.Fixtures: %w", err)
+	}
+
+	// An empty report would read as a passed suite.
+	if len(fixtures) == 0 {
+		return nil, fmt.Errorf("%w: %s", ErrNoFixtures, pluginName)
+	}
+
+	report, err := c.conformance(ctx, group, name, version, fixtures, update)
+	if err != nil {
+		return nil, fmt.Errorf("c.conformance: %w", err)
+	}
+
+	return report, nil
+}
+
+// ConformanceAll runs Conformance for every registered plugin with fixtures.
+func (c *Core) ConformanceAll(ctx context.Context, update bool) ([]ConformanceReport, error) {
+	infos, err := c.registry.List(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("c.registry.List: %w", err)
+	}
+
+	var reports []ConformanceReport
+	for _, info := range infos {
+		fixtures, err := c.fixtures.Fixtures(ctx, info.Group, info.Name, info.Version)
+		if err != nil {
+			return nil, fmt.Errorf("c.fixtures.Fixtures: %w", err)
+		}
+
+		if len(fixtures) == 0 {
+			continue
+		}
+
+		report, err := c.conformance(ctx, info.Group, info.Name, info.Version, fixtures, update)
+		if err != nil {
+			return nil, fmt.Errorf("c.conformance: %w", err)
+		}
+
+		reports = append(reports, *report)
+	}
+
+	return reports, nil
+}
+
+func (c *Core) conformance(ctx context.Context, group, name, version string, fixtures []Fixture, update bool) (*ConformanceReport, error) {
+	plugin, err := c.registry.Get(ctx, group, name, version)
+	if err != nil {
+		return nil, fmt.Errorf("c.registry.Get: %w", err)
+	}
+
+	report := &ConformanceReport{Plugin: group + "/" + name + ":" + version}
+	for _, fixture := range fixtures {
+		result := ConformanceCase{Name: fixture.Name}
+
+		var resp *pluginpb.CodeGeneratorResponse
+		req, _, err := withParameter(plugin, fixture.Request)
+		if err == nil {
+			resp, err = plugin.Generate(ctx, req)
+		}
+
+		if err != nil {
+			result.Error = err.Error()
+		} else {
+			result.Error, result.Files = CompareResponses(fixture.Response, resp)
+		}
+
+		result.Passed = result.Error == "" && len(result.Files) == 0
+		if update && !result.Passed && err == nil {
+			fixture.Response = resp
+			err = c.fixtures.UpdateFixture(ctx, group, name, version, fixture)
+			if err != nil {
+				return nil, fmt.Errorf("c.fixtures.UpdateFixture: %w", err)
+			}
+
+			result.Updated = true
+		}
+
+		if result.Passed {
+			report.Passed++
+		} else {
+			report.Failed++
+		}
+
+		report.Cases = append(report.Cases, result)
+	}
+
+	return report, nil
+}
+
+// CompareResponses returns the mismatch of the plugin error and the supported features
+// and the differences of the files, empty when the actual response is equal to the expected one.
+func CompareResponses(expected, actual *pluginpb.CodeGeneratorResponse) (string, []FileDiff) {
+	switch {
+	case expected == nil:
+		return "no expected response", nil
+	case expected.GetError() != actual.GetError():
+		return fmt.Sprintf("plugin error: expected %q, got %q", expected.GetError(), actual.GetError()), nil
+	case expected.GetSupportedFeatures() != actual.GetSupportedFeatures():
+		return fmt.Sprintf("supported features: expected %d, got %d", expected.GetSupportedFeatures(), actual.GetSupportedFeatures()), nil
+	}
+
+	return "", diffOutputs(outputContents(expected), outputContents(actual)).Files
+}
@@ -4,25 +4,97 @@ package core
 import (
 	"context"
 	"fmt"
+	"slices"
 	"strings"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/pluginpb"
 )
 
 // Core defines the interface for interacting with the plugin server.
 type Core struct {
-	metrics  Metrics
-	registry Registry
+	metrics     Metrics
+	registry    Registry
+	descriptors DescriptorStore
+	secrets     SecretStore
+	modules     ModuleStore
+	fixtures    FixtureStore
 }
 
 // New creates a new Core instance.
-func New(metrics Metrics, registry Registry) *Core {
+func New(metrics Metrics, registry Registry, descriptors DescriptorStore, secrets SecretStore, modules ModuleStore, fixtures FixtureStore) *Core {
 	return &Core{
-		metrics:  metrics,
-		registry: registry,
+		metrics:     metrics,
+		registry:    registry,
+		descriptors: descriptors,
+		secrets:     secrets,
+		modules:     modules,
+		fixtures:    fixtures,
 	}
 }
 
 // Generate generates code by plugin.
 func (c *Core) Generate(ctx context.Context, req GenerateCodeRequest) (*GenerateCodeResponse, error) {
+	plugin, err := c.plugin(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("c.plugin: %w", err)
+	}
+
+	plugins, err := c.resolvePlugins(ctx, req.Plugins)
+	if err != nil {
+		return nil, fmt.Errorf("c.resolvePlugins: %w", err)
+	}
+
+	warnings, err := checkCompatibility(plugin.Info(ctx), req.Payload, plugins)
+	if err != nil {
+		return nil, fmt.Errorf("checkCompatibility: %w", err)
+	}
+
+	return c.generate(ctx, plugin, req, warnings)
+}
+
+// GenerateBatch generates code by several plugins. Every plugin is resolved and checked
+// before any of them is run, plugins of the batch are generated together with each other.
+func (c *Core) GenerateBatch(ctx context.Context, reqs []GenerateCodeRequest) ([]GenerateCodeResponse, error) {
+	plugins := make([]Plugin, len(reqs))
+	infos := make([]PluginInfo, len(reqs))
+	for i := range reqs {
+		plugin, err := c.plugin(ctx, reqs[i])
+		if err != nil {
+			return nil, fmt.Errorf("c.plugin: %w", err)
+		}
+
+		plugins[i], infos[i] = plugin, *plugin.Info(ctx)
+	}
+
+	warnings := make([][]string, len(reqs))
+	for i := range reqs {
+		others, err := c.resolvePlugins(ctx, reqs[i].Plugins)
+		if err != nil {
+			return nil, fmt.Errorf("c.resolvePlugins: %w", err)
+		}
+
+		warnings[i], err = checkCompatibility(&infos[i], reqs[i].Payload, slices.Concat(infos[:i], infos[i+1:], others))
+		if err != nil {
+			return nil, fmt.Errorf("checkCompatibility: %w", err)
+		}
+	}
+
+	resps := make([]GenerateCodeResponse, len(reqs))
+	for i := range reqs {
+		resp, err := c.generate(ctx, plugins[i], reqs[i], warnings[i])
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", reqs[i].PluginName, err)
+		}
+
+		resps[i] = *resp
+	}
+
+	return resps, nil
+}
+
+// plugin resolves the plugin of the request and validates the parameter.
+func (c *Core) plugin(ctx context.Context, req GenerateCodeRequest) (Plugin, error) {
 	group, err := getGroup(req.PluginName)
 	if err != nil {
 		return nil, fmt.Errorf("getGroup: %w", err)
@@ -38,21 +110,103 @@ func (c *Core) Generate(ctx context.Context, req GenerateCodeRequest) (*Generate
 		return nil, fmt.Errorf("c.registry.Get: %w", err)
 	}
 
-	generatedCode, err := plugin.Generate(ctx, req.Payload)
+	err = ValidateParameter(plugin.Info(ctx).Manifest.Options, req.Payload.GetParameter())
+	if err != nil {
+		return nil, fmt.Errorf("ValidateParameter: %w", err)
+	}
+
+	for _, ref := range req.Modules {
+		_, _, err = parseModuleRef(ref)
+		if err != nil {
+			return nil, fmt.Errorf("parseModuleRef: %w", err)
+		}
+	}
+
+	if req.PostProcess != nil {
+		err = req.PostProcess.Check()
+		if err != nil {
+			return nil, fmt.Errorf("%w: post_process: %w", ErrInvalidArgument, err)
+		}
+	}
+
+	return plugin, nil
+}
+
+func (c *Core) generate(ctx context.Context, plugin Plugin, req GenerateCodeRequest, warnings []string) (*GenerateCodeResponse, error) {
+	payload, err := c.rehydrate(ctx, req.Payload, req.ProtoFileHashes)
+	if err != nil {
+		return nil, fmt.Errorf("c.rehydrate: %w", err)
+	}
+
+	payload, err = c.splice(ctx, payload, req.Modules)
+	if err != nil {
+		return nil, fmt.Errorf("c.splice: %w", err)
+	}
+
+	requested := payload.GetParameter()
+	payload, enforced, err := withParameter(plugin, payload)
+	if err != nil {
+		return nil, fmt.Errorf("withParameter: %w", err)
+	}
+
+	// Only client runs are audited, the reruns of determinism checks use the same parameter.
+	if enforced {
+		err = c.registry.AuditParameter(ctx, plugin.Info(ctx).ID, requested, payload.GetParameter())
+		if err != nil {
+			return nil, fmt.Errorf("c.registry.AuditParameter: %w", err)
+		}
+	}
+
+	generatedCode, err := plugin.Generate(ctx, payload)
 	if err != nil {
 		return nil, fmt.Errorf("plugin.Generate: %w", err)
 	}
 
-	err = c.metrics.GenerateCode(ctx, *plugin.Info(ctx))
+	var determinism *DeterminismCheck
+	if req.VerifyDeterminism {
+		determinism, err = c.verifyDeterminism(ctx, plugin, payload, generatedCode, DeterminismSourceRequest)
+		if err != nil {
+			return nil, fmt.Errorf("c.verifyDeterminism: %w", err)
+		}
+	}
+
+	info := plugin.Info(ctx)
+
+	generatedCode, err = info.PostProcess.override(req.PostProcess).apply(generatedCode)
+	if err != nil {
+		return nil, fmt.Errorf("PostProcess.apply: %w", err)
+	}
+
+	err = c.metrics.GenerateCode(ctx, *info)
 	if err != nil {
 		return nil, fmt.Errorf("c.metrics.GenerateCode: %w", err)
 	}
 
 	return &GenerateCodeResponse{
-		Payload: generatedCode,
+		Payload:     generatedCode,
+		Warnings:    warnings,
+		Determinism: determinism,
 	}, nil
 }
 
+// withParameter returns a copy of the request with the parameter the plugin runs with,
+// enforced reports whether the plugin enforces default or locked options.
+func withParameter(plugin Plugin, req *pluginpb.CodeGeneratorRequest) (*pluginpb.CodeGeneratorRequest, bool, error) {
+	effective, enforced, err := plugin.Parameter(req.GetParameter())
+	if err != nil {
+		return nil, false, fmt.Errorf("plugin.Parameter: %w", err)
+	}
+
+	if !enforced {
+		return req, false, nil
+	}
+
+	req = proto.CloneOf(req)
+	req.Parameter = proto.String(effective)
+
+	return req, true, nil
+}
+
 func getGroup(pluginName string) (string, error) {
 	splitArray := strings.Split(pluginName, "/")
 	if len(splitArray) != 2 {
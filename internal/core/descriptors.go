@@ -0,0 +1,94 @@
+package core
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/pluginpb"
+)
+
+// DescriptorHash returns the content address of the descriptor:
+// hex-encoded SHA-256 of its deterministic binary encoding.
+func DescriptorHash(file *descriptorpb.FileDescriptorProto) (string, error) {
+	data, err := proto.MarshalOptions{Deterministic: true}.Marshal(file)
+	if err != nil {
+		return "", fmt.Errorf("proto.Marshal: %w", err)
+	}
+
+	sum := sha256.Sum256(data)
+
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// MissingDescriptors returns the hashes which should be uploaded before referencing them.
+func (c *Core) MissingDescriptors(ctx context.Context, hashes []string) ([]string, error) {
+	missing, err := c.descriptors.MissingDescriptors(ctx, hashes)
+	if err != nil {
+		return nil, fmt.Errorf("c.descriptors.MissingDescriptors: %w", err)
+	}
+
+	return missing, nil
+}
+
+// UploadDescriptors stores descriptors and returns their hashes in the same order.
+func (c *Core) UploadDescriptors(ctx context.Context, files []*descriptorpb.FileDescriptorProto) ([]string, error) {
+	hashes := make([]string, len(files))
+	descriptors := make(map[string]*descriptorpb.FileDescriptorProto, len(files))
+	for i, file := range files {
+		hash, err := DescriptorHash(file)
+		if err != nil {
+			return nil, fmt.Errorf("DescriptorHash: %w", err)
+		}
+
+		hashes[i] = hash
+		descriptors[hash] = file
+	}
+
+	err := c.descriptors.SaveDescriptors(ctx, descriptors)
+	if err != nil {
+		return nil, fmt.Errorf("c.descriptors.SaveDescriptors: %w", err)
+	}
+
+	return hashes, nil
+}
+
+// rehydrate returns the request with the referenced descriptors placed before the inline ones.
+func (c *Core) rehydrate(ctx context.Context, req *pluginpb.CodeGeneratorRequest, hashes []string) (*pluginpb.CodeGeneratorRequest, error) {
+	if len(hashes) == 0 {
+		return req, nil
+	}
+
+	descriptors, err := c.descriptors.Descriptors(ctx, hashes)
+	if err != nil {
+		return nil, fmt.Errorf("c.descriptors.Descriptors: %w", err)
+	}
+
+	var missing []string
+	protoFiles := make([]*descriptorpb.FileDescriptorProto, 0, len(hashes)+len(req.GetProtoFile()))
+	for _, hash := range hashes {
+		file, ok := descriptors[hash]
+		if !ok {
+			missing = append(missing, hash)
+			continue
+		}
+
+		protoFiles = append(protoFiles, file)
+	}
+
+	if len(missing) > 0 {
+		return nil, fmt.Errorf("%w: %s", ErrMissingDescriptor, strings.Join(missing, ", "))
+	}
+
+	rehydrated := proto.CloneOf(req)
+	if rehydrated == nil {
+		rehydrated = &pluginpb.CodeGeneratorRequest{}
+	}
+	rehydrated.ProtoFile = append(protoFiles, req.GetProtoFile()...)
+
+	return rehydrated, nil
+}
@@ -0,0 +1,232 @@
+package core
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"maps"
+	"slices"
+	"time"
+
+	"github.com/sipki-tech/dev-platform/logger"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/pluginpb"
+)
+
+// Badges of determinism checks.
+const (
+	DeterminismPassed = "deterministic"
+	DeterminismFailed = "nondeterministic"
+	DeterminismError  = "error"
+)
+
+// Sources of determinism checks.
+const (
+	DeterminismSourceRequest    = "request"
+	DeterminismSourceBackground = "background"
+)
+
+// determinismSample is generated by the background checks.
+const determinismSample = `syntax = "proto3";
+
+package easyp.determinism.v1;
+
+import "google/protobuf/timestamp.proto";
+
+option go_package = "github.com/easyp-tech/determinism/gen/go/easyp/determinism/v1;determinismv1";
+option java_multiple_files = true;
+option java_package = "tech.easyp.determinism.v1";
+
+// SampleService is a service with every kind of method.
+service SampleService {
+  rpc Unary(Sample) returns (Sample);
+  rpc ServerStream(Sample) returns (stream Sample);
+  rpc ClientStream(stream Sample) returns (Sample);
+  rpc BidiStream(stream Sample) returns (stream Sample);
+}
+
+// Sample has fields of many kinds, so map ordering and the like show up in the output.
+message Sample {
+  enum Kind {
+    KIND_UNSPECIFIED = 0;
+    KIND_FIRST = 1;
+    KIND_SECOND = 2;
+  }
+
+  string id = 1;
+  Kind kind = 2;
+  map<string, string> labels = 3;
+  map<int64, Sample> children = 4;
+  repeated bytes chunks = 5;
+  google.protobuf.Timestamp created_at = 6;
+  oneof value {
+    double number = 7;
+    string text = 8;
+  }
+  optional int32 limit = 9;
+}
+`
+
+// Badge returns the badge of the check.
+func (c DeterminismCheck) Badge() string {
+	switch {
+	case c.Error != "":
+		return DeterminismError
+	case len(c.Differences) > 0:
+		return DeterminismFailed
+	default:
+		return DeterminismPassed
+	}
+}
+
+// verifyDeterminism runs the request again, compares the outputs and stores the result.
+func (c *Core) verifyDeterminism(ctx context.Context, plugin Plugin, req *pluginpb.CodeGeneratorRequest, first *pluginpb.CodeGeneratorResponse, source string) (*DeterminismCheck, error) {
+	check := DeterminismCheck{Source: source, CheckedAt: time.Now().UTC()}
+
+	second, err := plugin.Generate(ctx, req)
+	if err != nil {
+		check.Error = err.Error()
+	} else {
+		check.Differences = outputDifferences(first, second)
+	}
+
+	err = c.registry.SaveDeterminism(ctx, plugin.Info(ctx).ID, check)
+	if err != nil {
+		return nil, fmt.Errorf("c.registry.SaveDeterminism: %w", err)
+	}
+
+	return &check, nil
+}
+
+// outputDifferences returns the files whose contents differ, by name and insertion point.
+func outputDifferences(a, b *pluginpb.CodeGeneratorResponse) []string {
+	var differences []string
+	if a.GetError() != b.GetError() {
+		differences = append(differences, "error")
+	}
+
+	filesA, filesB := outputContents(a), outputContents(b)
+	for _, name := range slices.Sorted(maps.Keys(filesA)) {
+		if content, ok := filesB[name]; !ok || content != filesA[name] {
+			differences = append(differences, name)
+		}
+	}
+
+	for _, name := range slices.Sorted(maps.Keys(filesB)) {
+		if _, ok := filesA[name]; !ok {
+			differences = append(differences, name)
+		}
+	}
+
+	return differences
+}
+
+// outputContents returns the contents of the files, continuations are joined with their files.
+func outputContents(resp *pluginpb.CodeGeneratorResponse) map[string]string {
+	contents := make(map[string]string, len(resp.GetFile()))
+
+	var last string
+	for _, file := range resp.GetFile() {
+		if file.Name != nil {
+			last = file.GetName()
+			if file.GetInsertionPoint() != "" {
+				last += "@" + file.GetInsertionPoint()
+			}
+		}
+
+		contents[last] += file.GetContent()
+	}
+
+	return contents
+}
+
+// CheckDeterminism generates a sample by every plugin twice and stores the results.
+func (c *Core) CheckDeterminism(ctx context.Context) error {
+	files, compileErrors, err := compileSources(ctx, []SourceFile{{Path: "easyp/determinism/v1/sample.proto", Content: determinismSample}}, nil, nil)
+	switch {
+	case err != nil:
+		return fmt.Errorf("compileSources: %w", err)
+	case len(compileErrors) > 0:
+		return fmt.Errorf("compileSources: %s", compileErrors[0].Message)
+	}
+
+	infos, err := c.registry.List(ctx)
+	if err != nil {
+		return fmt.Errorf("c.registry.List: %w", err)
+	}
+
+	log := logger.FromContext(ctx)
+	for _, info := range infos {
+		req := &pluginpb.CodeGeneratorRequest{
+			FileToGenerate: []string{files[len(files)-1].GetName()},
+			Parameter:      proto.String(""),
+			ProtoFile:      files,
+		}
+
+		check, err := c.checkPluginDeterminism(ctx, info, req)
+		switch {
+		case ctx.Err() != nil:
+			return nil
+		case err != nil:
+			log.Error("check determinism", slog.String("plugin", info.Group+"/"+info.Name+":"+info.Version), slog.String(logger.Error.String(), err.Error()))
+		case check.Badge() != DeterminismPassed:
+			log.Warn("check determinism", slog.String("plugin", info.Group+"/"+info.Name+":"+info.Version), slog.String("badge", check.Badge()))
+		}
+	}
+
+	return nil
+}
+
+func (c *Core) checkPluginDeterminism(ctx context.Context, info PluginInfo, req *pluginpb.CodeGeneratorRequest) (*DeterminismCheck, error) {
+	plugin, err := c.registry.Get(ctx, info.Group, info.Name, info.Version)
+	if err != nil {
+		return nil, fmt.Errorf("c.registry.Get: %w", err)
+	}
+
+	var first *pluginpb.CodeGeneratorResponse
+	req, _, err = withParameter(plugin, req)
+	if err == nil {
+		first, err = plugin.Generate(ctx, req)
+	}
+
+	if err != nil {
+		check := DeterminismCheck{Source: DeterminismSourceBackground, CheckedAt: time.Now().UTC(), Error: err.Error()}
+		err = c.registry.SaveDeterminism(ctx, info.ID, check)
+		if err != nil {
+			return nil, fmt.Errorf("c.registry.SaveDeterminism: %w", err)
+		}
+
+		return &check, nil
+	}
+
+	check, err := c.verifyDeterminism(ctx, plugin, req, first, DeterminismSourceBackground)
+	if err != nil {
+		return nil, fmt.Errorf("c.verifyDeterminism: %w", err)
+	}
+
+	return check, nil
+}
+
+// RunDeterminismChecks runs CheckDeterminism every interval until ctx is done,
+// it does nothing when the interval is zero.
+func (c *Core) RunDeterminismChecks(ctx context.Context, interval time.Duration) error {
+	if interval <= 0 {
+		return nil
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+
+		err := c.CheckDeterminism(ctx)
+		if err != nil && ctx.Err() == nil {
+			logger.FromContext(ctx).Error("check determinism", slog.String(logger.Error.String(), err.Error()))
+		}
+	}
+}
@@ -0,0 +1,149 @@
+package core
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/pmezard/go-difflib/difflib"
+)
+
+// Statuses of files in a generation diff.
+const (
+	FileAdded    = "added"
+	FileRemoved  = "removed"
+	FileModified = "modified"
+)
+
+// diffContext is the number of unchanged lines around the changes of a unified diff.
+const diffContext = 3
+
+// noNewlineMarker follows the last line of a file which doesn't end with a newline.
+const noNewlineMarker = "\\ No newline at end of file\n"
+
+// DiffGeneration generates the request by two plugins, usually two versions of one plugin,
+// and compares the generated files.
+func (c *Core) DiffGeneration(ctx context.Context, req DiffGenerationRequest) (*DiffGenerationResponse, error) {
+	if req.Base == "" || req.Target == "" {
+		return nil, fmt.Errorf("%w: base and target plugins must be set", ErrInvalidArgument)
+	}
+
+	generate := func(pluginName string) (*GenerateCodeResponse, error) {
+		genReq := req.Request
+		genReq.PluginName = pluginName
+		genReq.VerifyDeterminism = false
+
+		return c.Generate(ctx, genReq)
+	}
+
+	base, err := generate(req.Base)
+	if err != nil {
+		return nil, fmt.Errorf("base %s: %w", req.Base, err)
+	}
+
+	target, err := generate(req.Target)
+	if err != nil {
+		return nil, fmt.Errorf("target %s: %w", req.Target, err)
+	}
+
+	resp := diffOutputs(outputContents(base.Payload), outputContents(target.Payload))
+	resp.Base, resp.Target = *base, *target
+
+	return resp, nil
+}
+
+// diffOutputs compares the files by name, unchanged files are only counted.
+func diffOutputs(base, target map[string]string) *DiffGenerationResponse {
+	resp := &DiffGenerationResponse{}
+
+	names := slices.Collect(maps.Keys(base))
+	for name := range maps.Keys(target) {
+		if _, ok := base[name]; !ok {
+			names = append(names, name)
+		}
+	}
+
+	slices.Sort(names)
+
+	for _, name := range names {
+		baseContent, inBase := base[name]
+		targetContent, inTarget := target[name]
+
+		diff := FileDiff{Name: name}
+		switch {
+		case !inBase:
+			diff.Status = FileAdded
+			resp.Summary.Added++
+		case !inTarget:
+			diff.Status = FileRemoved
+			resp.Summary.Removed++
+		case baseContent != targetContent:
+			diff.Status = FileModified
+			resp.Summary.Modified++
+		default:
+			resp.Summary.Unchanged++
+
+			continue
+		}
+
+		diff.Diff, diff.LinesAdded, diff.LinesRemoved = unifiedDiff(name, baseContent, targetContent, inBase, inTarget)
+		resp.Summary.LinesAdded += diff.LinesAdded
+		resp.Summary.LinesRemoved += diff.LinesRemoved
+		resp.Files = append(resp.Files, diff)
+	}
+
+	return resp
+}
+
+// unifiedDiff returns the diff in the git format, /dev/null stands for a missing file.
+func unifiedDiff(name, base, target string, inBase, inTarget bool) (diff string, added, removed int) {
+	baseLines, targetLines := diffLines(base), diffLines(target)
+	for _, op := range difflib.NewMatcher(baseLines, targetLines).GetOpCodes() {
+		switch op.Tag {
+		case 'r':
+			removed += op.I2 - op.I1
+			added += op.J2 - op.J1
+		case 'd':
+			removed += op.I2 - op.I1
+		case 'i':
+			added += op.J2 - op.J1
+		}
+	}
+
+	fromFile, toFile := "a/"+name, "b/"+name
+	if !inBase {
+		fromFile = "/dev/null"
+	}
+
+	if !inTarget {
+		toFile = "/dev/null"
+	}
+
+	// Writing to a strings.Builder doesn't fail.
+	diff, _ = difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+		A:        baseLines,
+		B:        targetLines,
+		FromFile: fromFile,
+		ToFile:   toFile,
+		Context:  diffContext,
+	})
+
+	return diff, added, removed
+}
+
+// diffLines splits the content into lines, each ending with a newline.
+// A last line without a newline is followed by the git marker, so adding or removing it is a change.
+func diffLines(content string) []string {
+	var lines []string
+	for line := range strings.Lines(content) {
+		if !strings.HasSuffix(line, "\n") {
+			line += "\n" + noNewlineMarker
+		}
+
+		lines = append(lines, line)
+	}
+
+	return lines
+}
@@ -0,0 +1,58 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestUnifiedDiff(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		base, target string
+		diff         string
+		added        int
+		removed      int
+	}{
+		"modified": {
+			base:    "a\nb\n",
+			target:  "a\nc\n",
+			diff:    "--- a/x.go\n+++ b/x.go\n@@ -1,2 +1,2 @@\n a\n-b\n+c\n",
+			added:   1,
+			removed: 1,
+		},
+		"newline added": {
+			base:    "a\nb",
+			target:  "a\nb\n",
+			diff:    "--- a/x.go\n+++ b/x.go\n@@ -1,2 +1,2 @@\n a\n-b\n\\ No newline at end of file\n+b\n",
+			added:   1,
+			removed: 1,
+		},
+		"newline removed": {
+			base:    "a\n",
+			target:  "a",
+			diff:    "--- a/x.go\n+++ b/x.go\n@@ -1 +1 @@\n-a\n+a\n\\ No newline at end of file\n",
+			added:   1,
+			removed: 1,
+		},
+		"no newline in both": {
+			base:    "a\nb",
+			target:  "c\nb",
+			diff:    "--- a/x.go\n+++ b/x.go\n@@ -1,2 +1,2 @@\n-a\n+c\n b\n\\ No newline at end of file\n",
+			added:   1,
+			removed: 1,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			diff, added, removed := unifiedDiff("x.go", tt.base, tt.target, true, true)
+			require.Equal(t, tt.diff, diff)
+			require.Equal(t, tt.added, added)
+			require.Equal(t, tt.removed, removed)
+		})
+	}
+}
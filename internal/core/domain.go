@@ -7,14 +7,24 @@ import (
 	"time"
 
 	"github.com/gofrs/uuid/v5"
+	"google.golang.org/protobuf/types/descriptorpb"
 	"google.golang.org/protobuf/types/pluginpb"
 )
 
 // Errors.
 var (
-	ErrNotFound          = errors.New("not found")
-	ErrInvalidPluginName = errors.New("invalid plugin name")
-	ErrGenerationFailed  = errors.New("code generation failed")
+	ErrNotFound            = errors.New("not found")
+	ErrInvalidPluginName   = errors.New("invalid plugin name")
+	ErrGenerationFailed    = errors.New("code generation failed")
+	ErrMissingDescriptor   = errors.New("missing descriptor")
+	ErrInvalidPluginConfig = errors.New("invalid plugin config")
+	ErrAlreadyExists       = errors.New("already exists")
+	ErrSecretsDisabled     = errors.New("secrets are disabled")
+	ErrInvalidArgument     = errors.New("invalid argument")
+	ErrUnsignedImage       = errors.New("image is not signed")
+	ErrInvalidSignature    = errors.New("image signature is invalid")
+	ErrIncompatiblePlugin  = errors.New("incompatible plugin")
+	ErrNoFixtures          = errors.New("no conformance fixtures")
 )
 
 type (
@@ -31,6 +41,62 @@ type (
 		// The pluginName parameter specifies the plugin to retrieve (e.g., "protobuf/go:v1.36.9").
 		// Returns an error if the plugin is not found or cannot be loaded.
 		Get(ctx context.Context, pluginGroup, pluginName, pluginVersion string) (Plugin, error)
+		// Register validates the config and adds the plugin version.
+		// Returns ErrInvalidPluginConfig if the config or the image is rejected and ErrAlreadyExists
+		// if the version is already registered.
+		Register(ctx context.Context, req RegisterPluginRequest) (*PluginInfo, error)
+		// List returns all registered plugin versions.
+		List(ctx context.Context) ([]PluginInfo, error)
+		// AddSignature stores a signature of the image digest, used when the group requires signed images.
+		AddSignature(ctx context.Context, digest string, payload []byte, signature string) error
+		// SyncCatalog upserts plugins of the images in the registry catalog.
+		SyncCatalog(ctx context.Context) (*CatalogReport, error)
+		// SaveDeterminism replaces the last determinism check of the plugin.
+		SaveDeterminism(ctx context.Context, pluginID uuid.UUID, check DeterminismCheck) error
+		// AuditParameter records the requested and the effective parameter of a run of the plugin.
+		AuditParameter(ctx context.Context, pluginID uuid.UUID, requested, effective string) error
+	}
+
+	// DescriptorStore is a content-addressed storage of file descriptors.
+	DescriptorStore interface {
+		// MissingDescriptors returns the hashes which are not present in the store.
+		MissingDescriptors(ctx context.Context, hashes []string) ([]string, error)
+		// SaveDescriptors stores descriptors keyed by their hashes.
+		// Already stored descriptors are skipped.
+		SaveDescriptors(ctx context.Context, descriptors map[string]*descriptorpb.FileDescriptorProto) error
+		// Descriptors returns stored descriptors keyed by their hashes.
+		// Hashes which are not present in the store are omitted from the result.
+		Descriptors(ctx context.Context, hashes []string) (map[string]*descriptorpb.FileDescriptorProto, error)
+	}
+
+	// SecretStore keeps credentials which are injected into plugins by name.
+	// Values are write-only: they are never returned by the store.
+	SecretStore interface {
+		// PutSecret creates or replaces the secret.
+		PutSecret(ctx context.Context, name, value string) (*SecretInfo, error)
+		// DeleteSecret removes the secret, returns ErrNotFound if it doesn't exist.
+		DeleteSecret(ctx context.Context, name string) error
+		// Secrets returns all stored secrets.
+		Secrets(ctx context.Context) ([]SecretInfo, error)
+	}
+
+	// ModuleStore keeps versions of dependency modules, e.g. googleapis, as file descriptors.
+	// Versions are immutable.
+	ModuleStore interface {
+		// PutModule stores the module version, returns ErrAlreadyExists if it is already stored.
+		PutModule(ctx context.Context, name, version string, files []*descriptorpb.FileDescriptorProto) (*ModuleInfo, error)
+		// Module returns files of the module version, returns ErrNotFound if it doesn't exist.
+		Module(ctx context.Context, name, version string) ([]*descriptorpb.FileDescriptorProto, error)
+		// Modules returns all stored module versions.
+		Modules(ctx context.Context) ([]ModuleInfo, error)
+	}
+
+	// FixtureStore keeps recorded requests of plugins with their expected responses.
+	FixtureStore interface {
+		// Fixtures returns the fixtures of the plugin version sorted by name, none if it has no fixtures.
+		Fixtures(ctx context.Context, group, name, version string) ([]Fixture, error)
+		// UpdateFixture replaces the expected response of the fixture.
+		UpdateFixture(ctx context.Context, group, name, version string, fixture Fixture) error
 	}
 
 	// Plugin represents a code generator plugin that processes protobuf definitions.
@@ -38,9 +104,13 @@ type (
 		// Generate processes a code generation request and produces generated code.
 		// Takes a protobuf CodeGeneratorRequest and returns a CodeGeneratorResponse
 		// containing the generated files or an error if generation fails.
+		// The request must carry the parameter returned by Parameter.
 		Generate(ctx context.Context, req *pluginpb.CodeGeneratorRequest) (*pluginpb.CodeGeneratorResponse, error)
 		// Info retrieves information about a plugin by its identifier.
 		Info(ctx context.Context) *PluginInfo
+		// Parameter returns the parameter the plugin runs with, enforced reports whether the plugin
+		// enforces default or locked options.
+		Parameter(parameter string) (effective string, enforced bool, err error)
 	}
 
 	// GenerateCodeRequest represents an incoming request to generate code using a specific plugin.
@@ -50,12 +120,249 @@ type (
 		PluginName string
 		// Payload contains the protobuf code generation request with source files and parameters.
 		Payload *pluginpb.CodeGeneratorRequest
+		// ProtoFileHashes references descriptors from the DescriptorStore, in dependency order.
+		// They are placed before the inline Payload.ProtoFile entries.
+		ProtoFileHashes []string
+		// Plugins are the other plugins generated together with this one, in the PluginName format.
+		// They are checked against the compatibility constraints of the plugin.
+		Plugins []string
+		// PostProcess overrides the post-processing of the plugin, nil keeps it.
+		PostProcess *PostProcess
+		// Modules are "<name>@<version>" references of dependency modules, files of the modules
+		// imported by the request are placed before its files.
+		Modules []string
+		// VerifyDeterminism runs the plugin twice and compares the outputs.
+		VerifyDeterminism bool
 	}
 
 	// GenerateCodeResponse wraps the response from a code generation operation.
 	GenerateCodeResponse struct {
 		// Payload contains the protobuf code generation response with generated files.
 		Payload *pluginpb.CodeGeneratorResponse
+		// Warnings are violated compatibility constraints of the plugin in the CompatibilityWarn mode.
+		Warnings []string
+		// Determinism is the result of the check when the request verifies determinism.
+		Determinism *DeterminismCheck
+	}
+
+	// DiffGenerationRequest is a request generated by two plugins to compare their outputs.
+	DiffGenerationRequest struct {
+		// Base and Target are the compared plugins, e.g. "protobuf/go:v1.36.9" and "protobuf/go:v1.36.10".
+		Base   string
+		Target string
+		// Request is generated by both plugins, its PluginName is ignored.
+		Request GenerateCodeRequest
+	}
+
+	// DiffGenerationResponse are the files which differ between the outputs of the plugins.
+	DiffGenerationResponse struct {
+		// Files are sorted by name, "<name>@<insertion point>" for insertion points.
+		Files   []FileDiff
+		Summary DiffSummary
+		// Base and Target are the responses of the plugins.
+		Base   GenerateCodeResponse
+		Target GenerateCodeResponse
+	}
+
+	// FileDiff is a file which differs between the outputs.
+	FileDiff struct {
+		Name string
+		// Status is FileAdded, FileRemoved or FileModified.
+		Status string
+		// Diff is the unified diff of the file.
+		Diff         string
+		LinesAdded   int
+		LinesRemoved int
+	}
+
+	// DiffSummary counts the differences of the outputs.
+	DiffSummary struct {
+		Added        int
+		Removed      int
+		Modified     int
+		Unchanged    int
+		LinesAdded   int
+		LinesRemoved int
+	}
+
+	// Fixture is a recorded request of a plugin with the expected response.
+	Fixture struct {
+		Name    string
+		Request *pluginpb.CodeGeneratorRequest
+		// Response is nil if it wasn't recorded yet.
+		Response *pluginpb.CodeGeneratorResponse
+	}
+
+	// ConformanceReport is the result of running the fixtures of a plugin.
+	ConformanceReport struct {
+		// Plugin is the plugin name, e.g. "protobuf/go:v1.36.10".
+		Plugin string
+		Cases  []ConformanceCase
+		Passed int
+		Failed int
+	}
+
+	// ConformanceCase is the result of a fixture.
+	ConformanceCase struct {
+		Name   string
+		Passed bool
+		// Error is the error of the run or the mismatch of the plugin error and the supported features.
+		Error string
+		// Files are the differences from the expected files to the generated ones.
+		Files []FileDiff
+		// Updated is set when the expected response was replaced by the generated one.
+		Updated bool
+	}
+
+	// DeterminismCheck is the result of running a plugin twice on the same request.
+	DeterminismCheck struct {
+		// Differences are the files whose contents differ between the runs, by name,
+		// "<name>@<insertion point>" for insertion points and "error" for the plugin error.
+		Differences []string
+		// Error is the error of a run, the outputs are not compared then.
+		Error string
+		// Source is DeterminismSourceRequest or DeterminismSourceBackground.
+		Source    string
+		CheckedAt time.Time
+	}
+
+	// RegisterPluginRequest represents a new plugin version.
+	RegisterPluginRequest struct {
+		Group   string
+		Name    string
+		Version string
+		// Config is the JSON execution config of the plugin, interpreted by the Registry.
+		Config []byte
+		// Image is an explicit image reference, <registry>/<group>/<name>:<version> when empty.
+		Image string
+		// Registry is the name of a configured upstream registry, the default one when empty.
+		Registry string
+	}
+
+	// PluginManifest is the metadata of a plugin.
+	PluginManifest struct {
+		Description string
+		// Source is the upstream source of the plugin, e.g. a repository URL.
+		Source  string
+		License string
+		// Options are the options accepted in CodeGeneratorRequest.parameter.
+		Options []PluginOption
+	}
+
+	// PluginOption is a declared plugin option.
+	PluginOption struct {
+		// Name of the option, a trailing * matches any suffix, e.g. "M*".
+		Name        string
+		Description string
+		// Type is OptionString (also when empty), OptionBool or OptionInt.
+		Type string
+		// Values are the allowed values, any value of the type is allowed when empty.
+		Values  []string
+		Default string
+	}
+
+	// Compatibility are constraints on the environment the plugin is used in.
+	// Ranges are space separated comparisons, e.g. ">=v1.36.0 <v2", alternatives are separated by "||".
+	Compatibility struct {
+		// CompilerVersion is the range of CodeGeneratorRequest.compiler_version.
+		CompilerVersion string
+		// Plugins are version ranges of plugins generated together with this one by "<group>/<name>".
+		Plugins map[string]string
+		// Mode is CompatibilityWarn (also when empty) or CompatibilityFail.
+		Mode string
+	}
+
+	// PostProcess are steps applied to the generated files. Unset fields of a request override
+	// take the value of the plugin. Globs match file names, * and ? match within a path segment
+	// and ** matches any number of segments.
+	PostProcess struct {
+		// Header is prepended to every generated .go file, e.g. a license comment.
+		Header *string
+		// PathPrefix is prepended to names of the generated files.
+		PathPrefix *string
+		// Include are globs of the files to keep, every file is kept when empty.
+		Include []string
+		// Exclude are globs of the files to drop.
+		Exclude []string
+		// GoFormat formats .go files with go/format.
+		GoFormat *bool
+	}
+
+	// GenerateFromSourcesRequest are .proto sources compiled by the service and the plugins run on them.
+	GenerateFromSourcesRequest struct {
+		Files []SourceFile
+		// Modules are dependency modules the sources import: names of the linked ones, e.g. "googleapis",
+		// and "<name>@<version>" references of the stored ones.
+		Modules []string
+		// FileToGenerate are paths of the files to generate, every file of Files when empty.
+		FileToGenerate []string
+		// Plugins are run together, as GenerateBatch runs them.
+		Plugins []SourcePlugin
+	}
+
+	// SourceFile is a .proto file.
+	SourceFile struct {
+		// Path is the import path of the file, e.g. "api/v1/api.proto".
+		Path    string
+		Content string
+	}
+
+	// SourcePlugin is a plugin run on compiled sources.
+	SourcePlugin struct {
+		PluginName  string
+		Parameter   string
+		PostProcess *PostProcess
+	}
+
+	// GenerateFromSourcesResponse has either the responses of the plugins or the compile errors.
+	GenerateFromSourcesResponse struct {
+		// Responses are in the order of the plugins.
+		Responses []GenerateCodeResponse
+		Errors    []CompileError
+	}
+
+	// CompileError is an error in the sources.
+	CompileError struct {
+		File string
+		// Line and Column are 1-based, zero when unknown.
+		Line    int
+		Column  int
+		Message string
+	}
+
+	// CatalogReport is the result of a catalog sync.
+	CatalogReport struct {
+		// Created are plugins registered from new images.
+		Created []PluginInfo
+		// Updated are plugins whose config or manifest was replaced by the labels of the image.
+		Updated []PluginInfo
+		// OrphanedPlugins are plugins of the default registry without an image in the catalog.
+		OrphanedPlugins []PluginInfo
+		// OrphanedImages are images of the catalog which can't be registered.
+		OrphanedImages []OrphanedImage
+	}
+
+	// OrphanedImage is an image which can't be registered as a plugin.
+	OrphanedImage struct {
+		Image  string
+		Reason string
+	}
+
+	// ModuleInfo represents a stored version of a dependency module.
+	ModuleInfo struct {
+		// Name of the module, e.g. "github.com/googleapis/googleapis".
+		Name    string
+		Version string
+		// Files is the number of files of the module.
+		Files     int
+		CreatedAt time.Time
+	}
+
+	// SecretInfo represents a stored secret without its value.
+	SecretInfo struct {
+		Name      string
+		CreatedAt time.Time
+		UpdatedAt time.Time
 	}
 
 	// PluginInfo represents information about a plugin.
@@ -65,5 +372,21 @@ type (
 		Name      string
 		Version   string
 		CreatedAt time.Time
+		// Image is the resolved image reference of the plugin.
+		Image string
+		// Registry is the name of the upstream registry, empty for the default one.
+		Registry string
+		// Manifest is the metadata of the plugin from its image labels.
+		Manifest PluginManifest
+		// Compatibility are the declared compatibility constraints.
+		Compatibility Compatibility
+		// PostProcess are the post-processing steps of the generated files.
+		PostProcess PostProcess
+		// ImageStatus is the pre-pull state of the plugin image, empty if it is unknown.
+		ImageStatus string
+		// ImageError is the last pull error.
+		ImageError string
+		// Determinism is the last determinism check, nil if the plugin wasn't checked.
+		Determinism *DeterminismCheck
 	}
 )
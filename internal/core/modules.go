@@ -0,0 +1,142 @@
+package core
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/pluginpb"
+)
+
+var (
+	moduleNamePattern    = regexp.MustCompile(`^[a-z0-9][a-z0-9._-]*(/[a-z0-9][a-z0-9._-]*)*$`)
+	moduleVersionPattern = regexp.MustCompile(`^[A-Za-z0-9_][A-Za-z0-9_.+-]{0,127}$`)
+)
+
+// PutModule stores a version of a dependency module.
+func (c *Core) PutModule(ctx context.Context, name, version string, files []*descriptorpb.FileDescriptorProto) (*ModuleInfo, error) {
+	if !moduleNamePattern.MatchString(name) || !moduleVersionPattern.MatchString(version) {
+		return nil, fmt.Errorf("%w: module %s@%s: the name must match %s and the version %s",
+			ErrInvalidArgument, name, version, moduleNamePattern, moduleVersionPattern)
+	}
+
+	if len(files) == 0 {
+		return nil, fmt.Errorf("%w: module %s@%s has no files", ErrInvalidArgument, name, version)
+	}
+
+	paths := make(map[string]bool, len(files))
+	for _, file := range files {
+		if file.GetName() == "" || paths[file.GetName()] {
+			return nil, fmt.Errorf("%w: module %s@%s: file names must be set and unique, got %q", ErrInvalidArgument, name, version, file.GetName())
+		}
+
+		paths[file.GetName()] = true
+	}
+
+	info, err := c.modules.PutModule(ctx, name, version, files)
+	if err != nil {
+		return nil, fmt.Errorf("c.modules.PutModule: %w", err)
+	}
+
+	return info, nil
+}
+
+// Modules returns all stored module versions.
+func (c *Core) Modules(ctx context.Context) ([]ModuleInfo, error) {
+	modules, err := c.modules.Modules(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("c.modules.Modules: %w", err)
+	}
+
+	return modules, nil
+}
+
+// parseModuleRef splits the "name@version" reference of a module.
+func parseModuleRef(ref string) (name, version string, err error) {
+	name, version, ok := strings.Cut(ref, "@")
+	if !ok || !moduleNamePattern.MatchString(name) || !moduleVersionPattern.MatchString(version) {
+		return "", "", fmt.Errorf("%w: module %q must be <name>@<version>", ErrInvalidArgument, ref)
+	}
+
+	return name, version, nil
+}
+
+// moduleFiles returns files of the modules by path, a path can't be provided by two modules.
+func (c *Core) moduleFiles(ctx context.Context, refs []string) (map[string]*descriptorpb.FileDescriptorProto, error) {
+	files := make(map[string]*descriptorpb.FileDescriptorProto)
+	owners := make(map[string]string)
+
+	for _, ref := range refs {
+		name, version, err := parseModuleRef(ref)
+		if err != nil {
+			return nil, fmt.Errorf("parseModuleRef: %w", err)
+		}
+
+		moduleFiles, err := c.modules.Module(ctx, name, version)
+		if err != nil {
+			return nil, fmt.Errorf("c.modules.Module: %w", err)
+		}
+
+		for _, file := range moduleFiles {
+			if owner, ok := owners[file.GetName()]; ok && owner != ref {
+				return nil, fmt.Errorf("%w: file %s is in modules %s and %s", ErrInvalidArgument, file.GetName(), owner, ref)
+			}
+
+			files[file.GetName()], owners[file.GetName()] = file, ref
+		}
+	}
+
+	return files, nil
+}
+
+// splice returns the request with the module files it imports, directly or transitively,
+// placed before its files. Imports which no module provides are left to the plugin.
+func (c *Core) splice(ctx context.Context, req *pluginpb.CodeGeneratorRequest, refs []string) (*pluginpb.CodeGeneratorRequest, error) {
+	if len(refs) == 0 {
+		return req, nil
+	}
+
+	modules, err := c.moduleFiles(ctx, refs)
+	if err != nil {
+		return nil, fmt.Errorf("c.moduleFiles: %w", err)
+	}
+
+	present := make(map[string]bool, len(req.GetProtoFile()))
+	for _, file := range req.GetProtoFile() {
+		present[file.GetName()] = true
+	}
+
+	var spliced []*descriptorpb.FileDescriptorProto
+	var visit func(dependency string)
+	visit = func(dependency string) {
+		file, ok := modules[dependency]
+		if present[dependency] || !ok {
+			return
+		}
+
+		present[dependency] = true
+		for _, next := range file.GetDependency() {
+			visit(next)
+		}
+
+		spliced = append(spliced, file)
+	}
+
+	for _, file := range req.GetProtoFile() {
+		for _, dependency := range file.GetDependency() {
+			visit(dependency)
+		}
+	}
+
+	if len(spliced) == 0 {
+		return req, nil
+	}
+
+	result := proto.CloneOf(req)
+	result.ProtoFile = append(spliced, req.GetProtoFile()...)
+
+	return result, nil
+}
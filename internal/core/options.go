@@ -0,0 +1,132 @@
+package core
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// Types of plugin options.
+const (
+	OptionString = "string"
+	OptionBool   = "bool"
+	OptionInt    = "int"
+)
+
+var optionNamePattern = regexp.MustCompile(`^[A-Za-z0-9_][A-Za-z0-9_.-]*\*?$`)
+
+var errInvalidOption = errors.New("invalid option declaration")
+
+// Check validates the declaration of the option.
+func (o PluginOption) Check() error {
+	if !optionNamePattern.MatchString(o.Name) {
+		return fmt.Errorf("%w: name %q must match %s", errInvalidOption, o.Name, optionNamePattern)
+	}
+
+	switch o.Type {
+	case "", OptionString, OptionBool, OptionInt:
+	default:
+		return fmt.Errorf("%w: %s: unknown type %q", errInvalidOption, o.Name, o.Type)
+	}
+
+	for _, value := range o.Values {
+		err := o.checkType(value)
+		if err != nil {
+			return fmt.Errorf("%w: %s: %w", errInvalidOption, o.Name, err)
+		}
+	}
+
+	if o.Default != "" {
+		err := o.checkValue(o.Default)
+		if err != nil {
+			return fmt.Errorf("%w: %s: default: %w", errInvalidOption, o.Name, err)
+		}
+	}
+
+	return nil
+}
+
+// matches reports whether the option declares the parameter key.
+func (o PluginOption) matches(key string) bool {
+	if prefix, ok := strings.CutSuffix(o.Name, "*"); ok {
+		return strings.HasPrefix(key, prefix)
+	}
+
+	return o.Name == key
+}
+
+// checkValue checks the value against the type and the allowed values of the option.
+func (o PluginOption) checkValue(value string) error {
+	err := o.checkType(value)
+	if err != nil {
+		return err
+	}
+
+	if len(o.Values) > 0 && !slices.Contains(o.Values, value) {
+		return fmt.Errorf("value %q is not one of %s", value, strings.Join(o.Values, ", "))
+	}
+
+	return nil
+}
+
+func (o PluginOption) checkType(value string) error {
+	switch o.Type {
+	case OptionBool:
+		_, err := strconv.ParseBool(value)
+		if err != nil {
+			return fmt.Errorf("value %q is not a bool", value)
+		}
+	case OptionInt:
+		_, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return fmt.Errorf("value %q is not an int", value)
+		}
+	}
+
+	return nil
+}
+
+// ValidateParameter checks the comma separated key[=value] options of CodeGeneratorRequest.parameter
+// against the declared options. Every problem is reported at once as ErrInvalidArgument.
+// Parameters of plugins without declared options are not checked.
+func ValidateParameter(options []PluginOption, parameter string) error {
+	if len(options) == 0 || parameter == "" {
+		return nil
+	}
+
+	var problems []string
+	for _, item := range strings.Split(parameter, ",") {
+		if item == "" {
+			continue
+		}
+
+		key, value, hasValue := strings.Cut(item, "=")
+		idx := slices.IndexFunc(options, func(o PluginOption) bool { return o.matches(key) })
+		if idx == -1 {
+			problems = append(problems, fmt.Sprintf("unknown option %q", key))
+			continue
+		}
+
+		option := options[idx]
+		switch {
+		case !hasValue && option.Type == OptionBool:
+			// A bare flag of a bool option means true.
+		case !hasValue:
+			problems = append(problems, fmt.Sprintf("option %q requires a value", key))
+		default:
+			err := option.checkValue(value)
+			if err != nil {
+				problems = append(problems, fmt.Sprintf("option %q: %s", key, err))
+			}
+		}
+	}
+
+	if len(problems) > 0 {
+		return fmt.Errorf("%w: parameter: %s", ErrInvalidArgument, strings.Join(problems, "; "))
+	}
+
+	return nil
+}
@@ -0,0 +1,66 @@
+package core
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+)
+
+var (
+	pluginNamePattern    = regexp.MustCompile(`^[a-z0-9][a-z0-9._-]*$`)
+	pluginVersionPattern = regexp.MustCompile(`^v[0-9][0-9A-Za-z.+-]*$`)
+	digestPattern        = regexp.MustCompile(`^sha256:[a-f0-9]{64}$`)
+)
+
+// ValidPluginName reports whether the group, the name and the version can be registered.
+func ValidPluginName(group, name, version string) bool {
+	return pluginNamePattern.MatchString(group) && pluginNamePattern.MatchString(name) && pluginVersionPattern.MatchString(version)
+}
+
+// RegisterPlugin adds a plugin version to the registry.
+func (c *Core) RegisterPlugin(ctx context.Context, req RegisterPluginRequest) (*PluginInfo, error) {
+	if !ValidPluginName(req.Group, req.Name, req.Version) {
+		return nil, fmt.Errorf("%w: %s/%s:%s", ErrInvalidPluginName, req.Group, req.Name, req.Version)
+	}
+
+	info, err := c.registry.Register(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("c.registry.Register: %w", err)
+	}
+
+	return info, nil
+}
+
+// AddSignature stores a cosign signature of the image digest.
+func (c *Core) AddSignature(ctx context.Context, digest string, payload []byte, signature string) error {
+	if !digestPattern.MatchString(digest) {
+		return fmt.Errorf("%w: digest %q must match %s", ErrInvalidArgument, digest, digestPattern)
+	}
+
+	err := c.registry.AddSignature(ctx, digest, payload, signature)
+	if err != nil {
+		return fmt.Errorf("c.registry.AddSignature: %w", err)
+	}
+
+	return nil
+}
+
+// SyncCatalog registers images of the registry catalog and reports orphaned plugins and images.
+func (c *Core) SyncCatalog(ctx context.Context) (*CatalogReport, error) {
+	report, err := c.registry.SyncCatalog(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("c.registry.SyncCatalog: %w", err)
+	}
+
+	return report, nil
+}
+
+// Plugins returns all registered plugin versions.
+func (c *Core) Plugins(ctx context.Context) ([]PluginInfo, error) {
+	plugins, err := c.registry.List(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("c.registry.List: %w", err)
+	}
+
+	return plugins, nil
+}
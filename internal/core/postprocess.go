@@ -0,0 +1,218 @@
+package core
+
+import (
+	"errors"
+	"fmt"
+	"go/format"
+	"path"
+	"regexp"
+	"slices"
+	"strings"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/pluginpb"
+)
+
+var errInvalidPostProcess = errors.New("invalid post-processing")
+
+// Check validates the globs and the path prefix.
+func (p PostProcess) Check() error {
+	for _, glob := range slices.Concat(p.Include, p.Exclude) {
+		_, err := globRegexp(glob)
+		if err != nil {
+			return fmt.Errorf("%w: %w", errInvalidPostProcess, err)
+		}
+	}
+
+	if prefix := p.PathPrefix; prefix != nil && *prefix != "" {
+		clean := path.Clean(*prefix)
+		if path.IsAbs(clean) || clean == ".." || strings.HasPrefix(clean, "../") {
+			return fmt.Errorf("%w: path prefix %q must be relative and stay in the output directory", errInvalidPostProcess, *prefix)
+		}
+	}
+
+	return nil
+}
+
+// override returns the steps with the set fields of o replacing those of p.
+func (p PostProcess) override(o *PostProcess) PostProcess {
+	if o == nil {
+		return p
+	}
+
+	if o.Header != nil {
+		p.Header = o.Header
+	}
+
+	if o.PathPrefix != nil {
+		p.PathPrefix = o.PathPrefix
+	}
+
+	if o.Include != nil {
+		p.Include = o.Include
+	}
+
+	if o.Exclude != nil {
+		p.Exclude = o.Exclude
+	}
+
+	if o.GoFormat != nil {
+		p.GoFormat = o.GoFormat
+	}
+
+	return p
+}
+
+func (p PostProcess) empty() bool {
+	return (p.Header == nil || *p.Header == "") && (p.PathPrefix == nil || *p.PathPrefix == "") &&
+		len(p.Include) == 0 && len(p.Exclude) == 0 && (p.GoFormat == nil || !*p.GoFormat)
+}
+
+// apply filters, rewrites and formats the generated files, resp is left intact.
+// Files continuing a previous one (without a name) are joined with it first, insertion points
+// get neither the header nor formatting.
+func (p PostProcess) apply(resp *pluginpb.CodeGeneratorResponse) (*pluginpb.CodeGeneratorResponse, error) {
+	if p.empty() || resp.GetError() != "" {
+		return resp, nil
+	}
+
+	include, err := globsRegexp(p.Include)
+	if err != nil {
+		return nil, fmt.Errorf("globsRegexp: %w", err)
+	}
+
+	exclude, err := globsRegexp(p.Exclude)
+	if err != nil {
+		return nil, fmt.Errorf("globsRegexp: %w", err)
+	}
+
+	out := proto.CloneOf(resp)
+	files := out.File[:0]
+	keep := true
+
+	for _, file := range out.File {
+		if file.Name == nil {
+			if keep && len(files) > 0 {
+				last := files[len(files)-1]
+				last.Content = proto.String(last.GetContent() + file.GetContent())
+			}
+
+			continue
+		}
+
+		name := file.GetName()
+		keep = (include == nil || include.MatchString(name)) && (exclude == nil || !exclude.MatchString(name))
+		if keep {
+			files = append(files, file)
+		}
+	}
+
+	for _, file := range files {
+		if file.GetInsertionPoint() == "" {
+			err := p.rewrite(file)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		if p.PathPrefix != nil && *p.PathPrefix != "" {
+			file.Name = proto.String(path.Join(*p.PathPrefix, file.GetName()))
+		}
+	}
+
+	out.File = files
+
+	return out, nil
+}
+
+// rewrite adds the header and formats Go files, other files are left intact.
+func (p PostProcess) rewrite(file *pluginpb.CodeGeneratorResponse_File) error {
+	if !strings.HasSuffix(file.GetName(), ".go") {
+		return nil
+	}
+
+	content := file.GetContent()
+
+	if p.Header != nil && *p.Header != "" {
+		header := *p.Header
+		if !strings.HasSuffix(header, "\n") {
+			header += "\n"
+		}
+
+		content = header + content
+	}
+
+	if p.GoFormat != nil && *p.GoFormat {
+		formatted, err := format.Source([]byte(content))
+		if err != nil {
+			return fmt.Errorf("%w: format %s: %s", ErrGenerationFailed, file.GetName(), err)
+		}
+
+		content = string(formatted)
+	}
+
+	file.Content = proto.String(content)
+
+	return nil
+}
+
+// globsRegexp joins the globs into one regexp, it returns nil when there are no globs.
+func globsRegexp(globs []string) (*regexp.Regexp, error) {
+	if len(globs) == 0 {
+		return nil, nil //nolint:nilnil // No globs match nothing and are skipped.
+	}
+
+	patterns := make([]string, len(globs))
+	for i, glob := range globs {
+		re, err := globRegexp(glob)
+		if err != nil {
+			return nil, err
+		}
+
+		patterns[i] = re.String()
+	}
+
+	re, err := regexp.Compile(strings.Join(patterns, "|"))
+	if err != nil {
+		return nil, fmt.Errorf("regexp.Compile: %w", err)
+	}
+
+	return re, nil
+}
+
+// globRegexp converts a glob of slash separated file names to a regexp:
+// * matches within a path segment, ? matches one character and ** matches any number of segments.
+func globRegexp(glob string) (*regexp.Regexp, error) {
+	if glob == "" {
+		return nil, errors.New("empty glob")
+	}
+
+	var b strings.Builder
+	b.WriteString("^")
+
+	for i := 0; i < len(glob); i++ {
+		switch {
+		case strings.HasPrefix(glob[i:], "**/"):
+			b.WriteString("(?:.*/)?")
+			i += 2
+		case strings.HasPrefix(glob[i:], "**"):
+			b.WriteString(".*")
+			i++
+		case glob[i] == '*':
+			b.WriteString("[^/]*")
+		case glob[i] == '?':
+			b.WriteString("[^/]")
+		default:
+			b.WriteString(regexp.QuoteMeta(glob[i : i+1]))
+		}
+	}
+
+	b.WriteString("$")
+
+	re, err := regexp.Compile(b.String())
+	if err != nil {
+		return nil, fmt.Errorf("glob %q: %w", glob, err)
+	}
+
+	return re, nil
+}
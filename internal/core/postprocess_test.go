@@ -0,0 +1,74 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/pluginpb"
+)
+
+func TestGlobRegexp(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		glob    string
+		match   []string
+		noMatch []string
+	}{
+		"star":            {glob: "*.go", match: []string{"a.go", ".go"}, noMatch: []string{"dir/a.go", "a.gox"}},
+		"star in segment": {glob: "api/*/v1.pb.go", match: []string{"api/web/v1.pb.go"}, noMatch: []string{"api/web/x/v1.pb.go"}},
+		"question mark":   {glob: "v?.go", match: []string{"v1.go"}, noMatch: []string{"v.go", "v12.go", "v/.go"}},
+		"double star dir": {glob: "**/*.go", match: []string{"a.go", "dir/a.go", "dir/sub/a.go"}, noMatch: []string{"a.json"}},
+		"double star end": {glob: "gen/**", match: []string{"gen/a.go", "gen/dir/a.go"}, noMatch: []string{"other/a.go"}},
+		"meta characters": {glob: "a+(b).go", match: []string{"a+(b).go"}, noMatch: []string{"aa(b).go"}},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			re, err := globRegexp(tt.glob)
+			require.NoError(t, err)
+
+			for _, file := range tt.match {
+				require.True(t, re.MatchString(file), file)
+			}
+
+			for _, file := range tt.noMatch {
+				require.False(t, re.MatchString(file), file)
+			}
+		})
+	}
+
+	t.Run("empty", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := globRegexp("")
+		require.Error(t, err)
+	})
+}
+
+func TestPostProcessApply(t *testing.T) {
+	t.Parallel()
+
+	resp := &pluginpb.CodeGeneratorResponse{File: []*pluginpb.CodeGeneratorResponse_File{
+		{Name: proto.String("a.pb.go"), Content: proto.String("package a\n")},
+		{Name: proto.String("a.swagger.json"), Content: proto.String("{}\n")},
+		{Name: proto.String("a_test.go"), Content: proto.String("package a\n")},
+	}}
+
+	out, err := PostProcess{
+		Header:     proto.String("// Code header."),
+		PathPrefix: proto.String("gen"),
+		Exclude:    []string{"**/*_test.go"},
+	}.apply(resp)
+	require.NoError(t, err)
+
+	require.Len(t, out.File, 2)
+	require.Equal(t, "gen/a.pb.go", out.File[0].GetName())
+	require.Equal(t, "// Code header.\npackage a\n", out.File[0].GetContent())
+	require.Equal(t, "gen/a.swagger.json", out.File[1].GetName())
+	require.Equal(t, "{}\n", out.File[1].GetContent())
+	require.Len(t, resp.File, 3)
+}
@@ -0,0 +1,47 @@
+package core
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+)
+
+var secretNamePattern = regexp.MustCompile(`^[a-z0-9][a-z0-9._-]*$`)
+
+// PutSecret creates or replaces the secret.
+func (c *Core) PutSecret(ctx context.Context, name, value string) (*SecretInfo, error) {
+	if !secretNamePattern.MatchString(name) {
+		return nil, fmt.Errorf("%w: secret name %q must match %s", ErrInvalidArgument, name, secretNamePattern)
+	}
+
+	if value == "" {
+		return nil, fmt.Errorf("%w: secret value is empty", ErrInvalidArgument)
+	}
+
+	info, err := c.secrets.PutSecret(ctx, name, value)
+	if err != nil {
+		return nil, fmt.Errorf("c.secrets.PutSecret: %w", err)
+	}
+
+	return info, nil
+}
+
+// DeleteSecret removes the secret.
+func (c *Core) DeleteSecret(ctx context.Context, name string) error {
+	err := c.secrets.DeleteSecret(ctx, name)
+	if err != nil {
+		return fmt.Errorf("c.secrets.DeleteSecret: %w", err)
+	}
+
+	return nil
+}
+
+// Secrets returns all stored secrets without values.
+func (c *Core) Secrets(ctx context.Context) ([]SecretInfo, error) {
+	secrets, err := c.secrets.Secrets(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("c.secrets.Secrets: %w", err)
+	}
+
+	return secrets, nil
+}
@@ -0,0 +1,260 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"path"
+	"slices"
+	"strings"
+
+	"github.com/bufbuild/protocompile"
+	"github.com/bufbuild/protocompile/linker"
+	"github.com/bufbuild/protocompile/reporter"
+	_ "github.com/grpc-ecosystem/grpc-gateway/v2/protoc-gen-openapiv2/options" // Files of the grpc-gateway module.
+	_ "google.golang.org/genproto/googleapis/api/annotations"                  // Files of the googleapis module.
+	_ "google.golang.org/genproto/googleapis/api/httpbody"
+	_ "google.golang.org/genproto/googleapis/rpc/code"
+	_ "google.golang.org/genproto/googleapis/rpc/errdetails"
+	_ "google.golang.org/genproto/googleapis/rpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/pluginpb"
+)
+
+// maxCompileErrors limits the compile errors of a request.
+const maxCompileErrors = 100
+
+// wellKnownModules are dependency modules linked into the service, by name, with their path prefixes.
+// Well-known types of google/protobuf are always available.
+var wellKnownModules = map[string][]string{
+	"googleapis":   {"google/api/", "google/rpc/"},
+	"grpc-gateway": {"protoc-gen-openapiv2/options/"},
+}
+
+var errTooManyErrors = errors.New("too many errors")
+
+// GenerateFromSources compiles the sources and runs the plugins on them like GenerateBatch.
+// Sources which don't compile are reported as CompileErrors of the response, not as an error.
+func (c *Core) GenerateFromSources(ctx context.Context, req GenerateFromSourcesRequest) (*GenerateFromSourcesResponse, error) {
+	err := req.validate()
+	if err != nil {
+		return nil, fmt.Errorf("req.validate: %w", err)
+	}
+
+	var linked, managed []string
+	for _, module := range req.Modules {
+		if strings.Contains(module, "@") {
+			managed = append(managed, module)
+		} else {
+			linked = append(linked, module)
+		}
+	}
+
+	moduleFiles, err := c.moduleFiles(ctx, managed)
+	if err != nil {
+		return nil, fmt.Errorf("c.moduleFiles: %w", err)
+	}
+
+	files, compileErrors, err := compileSources(ctx, req.Files, linked, moduleFiles)
+	if err != nil {
+		return nil, fmt.Errorf("compileSources: %w", err)
+	}
+
+	if len(compileErrors) > 0 {
+		return &GenerateFromSourcesResponse{Errors: compileErrors}, nil
+	}
+
+	fileToGenerate := req.FileToGenerate
+	if len(fileToGenerate) == 0 {
+		for _, file := range req.Files {
+			fileToGenerate = append(fileToGenerate, file.Path)
+		}
+	}
+
+	reqs := make([]GenerateCodeRequest, len(req.Plugins))
+	for i, plugin := range req.Plugins {
+		reqs[i] = GenerateCodeRequest{
+			PluginName: plugin.PluginName,
+			Payload: &pluginpb.CodeGeneratorRequest{
+				FileToGenerate: fileToGenerate,
+				Parameter:      proto.String(plugin.Parameter),
+				ProtoFile:      files,
+			},
+			PostProcess: plugin.PostProcess,
+		}
+	}
+
+	resps, err := c.GenerateBatch(ctx, reqs)
+	if err != nil {
+		return nil, fmt.Errorf("c.GenerateBatch: %w", err)
+	}
+
+	return &GenerateFromSourcesResponse{Responses: resps}, nil
+}
+
+func (req GenerateFromSourcesRequest) validate() error {
+	var problems []string
+
+	if len(req.Files) == 0 {
+		problems = append(problems, "no files")
+	}
+
+	if len(req.Plugins) == 0 {
+		problems = append(problems, "no plugins")
+	}
+
+	paths := make(map[string]bool, len(req.Files))
+	for _, file := range req.Files {
+		switch {
+		case !strings.HasSuffix(file.Path, ".proto") || path.IsAbs(file.Path) || path.Clean(file.Path) != file.Path || strings.HasPrefix(file.Path, "../"):
+			problems = append(problems, fmt.Sprintf("file %q must be a clean relative path of a .proto file", file.Path))
+		case paths[file.Path]:
+			problems = append(problems, fmt.Sprintf("file %q is duplicated", file.Path))
+		}
+
+		paths[file.Path] = true
+	}
+
+	for _, name := range req.FileToGenerate {
+		if !paths[name] {
+			problems = append(problems, fmt.Sprintf("file to generate %q is not one of the files", name))
+		}
+	}
+
+	for _, module := range req.Modules {
+		if strings.Contains(module, "@") {
+			_, _, err := parseModuleRef(module)
+			if err != nil {
+				problems = append(problems, fmt.Sprintf("module %q must be <name>@<version>", module))
+			}
+
+			continue
+		}
+
+		if _, ok := wellKnownModules[module]; !ok {
+			problems = append(problems, fmt.Sprintf("unknown module %q, known are %s", module, strings.Join(slices.Sorted(maps.Keys(wellKnownModules)), ", ")))
+		}
+	}
+
+	if len(problems) > 0 {
+		return fmt.Errorf("%w: %s", ErrInvalidArgument, strings.Join(problems, "; "))
+	}
+
+	return nil
+}
+
+// compileSources compiles the files and returns them with their dependencies in topological order.
+// Imports are resolved from the sources, the linked modules and the files of the stored modules.
+func compileSources(ctx context.Context, sources []SourceFile, modules []string, moduleFiles map[string]*descriptorpb.FileDescriptorProto) ([]*descriptorpb.FileDescriptorProto, []CompileError, error) {
+	contents := make(map[string]string, len(sources))
+	names := make([]string, len(sources))
+	for i, source := range sources {
+		contents[source.Path], names[i] = source.Content, source.Path
+	}
+
+	var compileErrors []CompileError
+	report := func(err reporter.ErrorWithPos) error {
+		pos := err.GetPosition()
+		compileErrors = append(compileErrors, CompileError{
+			File:    pos.Filename,
+			Line:    pos.Line,
+			Column:  pos.Col,
+			Message: err.Unwrap().Error(),
+		})
+		if len(compileErrors) >= maxCompileErrors {
+			return errTooManyErrors
+		}
+
+		return nil
+	}
+
+	compiler := protocompile.Compiler{
+		Resolver: protocompile.WithStandardImports(protocompile.CompositeResolver{
+			&protocompile.SourceResolver{Accessor: protocompile.SourceAccessorFromMap(contents)},
+			moduleResolver(modules),
+			protocompile.ResolverFunc(func(name string) (protocompile.SearchResult, error) {
+				file, ok := moduleFiles[name]
+				if !ok {
+					return protocompile.SearchResult{}, protoregistry.NotFound
+				}
+
+				return protocompile.SearchResult{Proto: file}, nil
+			}),
+		}),
+		SourceInfoMode: protocompile.SourceInfoStandard,
+		Reporter:       reporter.NewReporter(report, nil),
+	}
+
+	compiled, err := compiler.Compile(ctx, names...)
+
+	// Unresolved imports fail the compilation without being reported.
+	var posErr reporter.ErrorWithPos
+	if len(compileErrors) == 0 && errors.As(err, &posErr) {
+		_ = report(posErr)
+	}
+
+	switch {
+	case len(compileErrors) > 0:
+		return nil, compileErrors, nil
+	case err != nil:
+		return nil, nil, fmt.Errorf("compiler.Compile: %w", err)
+	}
+
+	return fileDescriptors(compiled), nil, nil
+}
+
+// moduleResolver resolves files of the modules from the descriptors linked into the service.
+func moduleResolver(modules []string) protocompile.Resolver {
+	return protocompile.ResolverFunc(func(name string) (protocompile.SearchResult, error) {
+		for _, module := range modules {
+			for _, prefix := range wellKnownModules[module] {
+				if !strings.HasPrefix(name, prefix) {
+					continue
+				}
+
+				fd, err := protoregistry.GlobalFiles.FindFileByPath(name)
+				if err != nil {
+					return protocompile.SearchResult{}, fmt.Errorf("protoregistry.GlobalFiles.FindFileByPath: %w", err)
+				}
+
+				return protocompile.SearchResult{Desc: fd}, nil
+			}
+		}
+
+		return protocompile.SearchResult{}, protoregistry.NotFound
+	})
+}
+
+// fileDescriptors returns the files with their imports, every file follows its dependencies.
+func fileDescriptors(files linker.Files) []*descriptorpb.FileDescriptorProto {
+	var (
+		result []*descriptorpb.FileDescriptorProto
+		seen   = make(map[string]bool)
+		visit  func(fd protoreflect.FileDescriptor)
+	)
+
+	visit = func(fd protoreflect.FileDescriptor) {
+		if seen[fd.Path()] {
+			return
+		}
+
+		seen[fd.Path()] = true
+		imports := fd.Imports()
+		for i := range imports.Len() {
+			visit(imports.Get(i).FileDescriptor)
+		}
+
+		result = append(result, protodesc.ToFileDescriptorProto(fd))
+	}
+
+	for _, file := range files {
+		visit(file)
+	}
+
+	return result
+}
@@ -0,0 +1,14 @@
+-- up
+create table determinism_checks
+(
+    plugin_id   uuid      not null references plugins (id) on delete cascade,
+    differences text[]    not null default '{}',
+    error       text      not null default '',
+    source      text      not null,
+    checked_at  timestamp not null,
+
+    primary key (plugin_id)
+);
+
+-- down
+drop table determinism_checks;
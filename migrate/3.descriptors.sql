@@ -0,0 +1,12 @@
+-- up
+create table descriptors
+(
+    hash       text      not null,
+    content    bytea     not null,
+    created_at timestamp not null default now(),
+
+    primary key (hash)
+);
+
+-- down
+drop table descriptors;
@@ -0,0 +1,16 @@
+-- up
+create table audit_log
+(
+    id         uuid      not null default gen_random_uuid(),
+    plugin_id  uuid      not null,
+    event      text      not null,
+    details    jsonb     not null default '{}',
+    created_at timestamp not null default now(),
+
+    primary key (id)
+);
+
+create index audit_log_plugin_id_idx on audit_log (plugin_id, created_at);
+
+-- down
+drop table audit_log;
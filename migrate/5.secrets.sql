@@ -0,0 +1,14 @@
+-- up
+create table secrets
+(
+    name       text      not null,
+    nonce      bytea     not null,
+    ciphertext bytea     not null,
+    created_at timestamp not null default now(),
+    updated_at timestamp not null default now(),
+
+    primary key (name)
+);
+
+-- down
+drop table secrets;
@@ -0,0 +1,13 @@
+-- up
+create table signatures
+(
+    digest     text      not null,
+    payload    bytea     not null,
+    signature  text      not null,
+    created_at timestamp not null default now(),
+
+    primary key (digest, signature)
+);
+
+-- down
+drop table signatures;
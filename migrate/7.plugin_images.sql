@@ -0,0 +1,9 @@
+-- up
+alter table plugins
+    add column image    text not null default '',
+    add column registry text not null default '';
+
+-- down
+alter table plugins
+    drop column image,
+    drop column registry;
@@ -0,0 +1,7 @@
+-- up
+alter table plugins
+    add column manifest jsonb not null default '{}';
+
+-- down
+alter table plugins
+    drop column manifest;
@@ -0,0 +1,25 @@
+-- up
+create table modules
+(
+    name       text      not null,
+    version    text      not null,
+    created_at timestamp not null default now(),
+
+    primary key (name, version)
+);
+
+create table module_files
+(
+    name     text    not null,
+    version  text    not null,
+    position integer not null,
+    path     text    not null,
+    hash     text    not null references descriptors (hash),
+
+    primary key (name, version, path),
+    foreign key (name, version) references modules (name, version) on delete cascade
+);
+
+-- down
+drop table module_files;
+drop table modules;
@@ -4,6 +4,8 @@
 
 REGISTRY="${1:-localhost:5005}"
 PUSH="${2}"
+# Optional cosign key, pushed images are signed when set.
+COSIGN_KEY="${COSIGN_KEY:-}"
 
 export DOCKER_DEFAULT_PLATFORM=linux/amd64
 
@@ -25,12 +27,24 @@ for dockerfile in $(find registry -name Dockerfile | sort); do
 
         echo "Building ${tag}..."
 
-        if docker build --platform linux/amd64 -t "${tag}" "${dir}"; then
+        # Optional default config of the plugin, registered by the catalog sync.
+        labels=()
+        if [ -f "${dir}/config.json" ]; then
+            labels=(--label "tech.easyp.plugin.config=$(tr -d '\n' < "${dir}/config.json")")
+        fi
+
+        if docker build --platform linux/amd64 "${labels[@]}" -t "${tag}" "${dir}"; then
             echo "✓ Built successfully"
 
             if [ "$PUSH" == "--push" ]; then
                 echo "Pushing ${tag}..."
                 docker push "${tag}"
+
+                if [ -n "$COSIGN_KEY" ]; then
+                    image=$(docker inspect --format='{{index .RepoDigests 0}}' "${tag}")
+                    echo "Signing ${image}..."
+                    cosign sign --yes --key "$COSIGN_KEY" --tlog-upload=false "${image}"
+                fi
             fi
         else
             echo "✗ Build failed"